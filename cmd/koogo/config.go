@@ -0,0 +1,156 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kootic/koogo/internal/config"
+)
+
+const (
+	outputFormatTable = "table"
+	outputFormatEnv   = "env"
+	outputFormatJSON  = "json"
+)
+
+var configOutputFormat string
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the effective configuration",
+}
+
+var configPrintCmd = &cobra.Command{
+	Use:   "print",
+	Short: "Print the effective configuration with secrets redacted",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		entries, err := config.Entries(configOptions(false))
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+
+		switch configOutputFormat {
+		case outputFormatTable:
+			return printConfigTable(out, entries)
+		case outputFormatEnv:
+			for _, entry := range entries {
+				if entry.IsSet {
+					fmt.Fprintf(out, "%s=%s\n", entry.Key, entry.Value)
+				}
+			}
+
+			return nil
+		case outputFormatJSON:
+			encoder := json.NewEncoder(out)
+			encoder.SetIndent("", "  ")
+
+			return encoder.Encode(entries)
+		default:
+			return fmt.Errorf("unknown output format %q", configOutputFormat)
+		}
+	},
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:           "validate",
+	Short:         "Validate the effective configuration and list every problem",
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, err := loadConfig(cmd.Context(), true)
+		if err == nil {
+			fmt.Fprintln(cmd.OutOrStdout(), "Config is valid")
+			return nil
+		}
+
+		problems := []error{err}
+
+		var fieldErrs config.FieldErrors
+		if errors.As(err, &fieldErrs) {
+			problems = problems[:0]
+			for _, fieldErr := range fieldErrs {
+				problems = append(problems, fieldErr)
+			}
+		}
+
+		fmt.Fprintln(cmd.ErrOrStderr(), "Config is invalid:")
+
+		for _, problem := range problems {
+			fmt.Fprintf(cmd.ErrOrStderr(), "  - %s\n", problem)
+		}
+
+		return fmt.Errorf("config has %d problem(s)", len(problems))
+	},
+}
+
+var configExplainCmd = &cobra.Command{
+	Use:   "explain KEY",
+	Short: "Explain which source supplied a config value and its default",
+	Example: `  koogo config explain KOO_DB_HOST
+  koogo config explain db.max_conns`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		entries, err := config.Entries(configOptions(false))
+		if err != nil {
+			return err
+		}
+
+		entry, ok := config.LookupEntry(entries, args[0])
+		if !ok {
+			return fmt.Errorf("unknown config key %q", config.NormalizeKey(args[0]))
+		}
+
+		w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "Key:\t%s\n", entry.Key)
+
+		if entry.IsSet {
+			fmt.Fprintf(w, "Value:\t%s\n", entry.Value)
+			fmt.Fprintf(w, "Source:\t%s\n", entry.Source)
+		} else {
+			fmt.Fprintln(w, "Value:\t<unset>")
+		}
+
+		if entry.HasDefault {
+			fmt.Fprintf(w, "Default:\t%s\n", entry.Default)
+		} else {
+			fmt.Fprintln(w, "Default:\t<none>")
+		}
+
+		fmt.Fprintf(w, "Required:\t%t\n", entry.Required)
+		fmt.Fprintf(w, "Secret:\t%t\n", entry.Secret)
+
+		return w.Flush()
+	},
+}
+
+func init() {
+	configPrintCmd.Flags().StringVarP(&configOutputFormat, "output", "o", outputFormatTable, "Output format: table, env or json")
+
+	configCmd.AddCommand(configPrintCmd, configValidateCmd, configExplainCmd)
+	rootCmd.AddCommand(configCmd)
+}
+
+func printConfigTable(out io.Writer, entries []config.Entry) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "KEY\tVALUE\tSOURCE")
+
+	for _, entry := range entries {
+		value, source := entry.Value, string(entry.Source)
+		if !entry.IsSet {
+			value, source = "<unset>", "-"
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\n", entry.Key, value, source)
+	}
+
+	return w.Flush()
+}
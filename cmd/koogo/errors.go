@@ -0,0 +1,71 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kootic/koogo/internal/server"
+	"github.com/kootic/koogo/pkg/koohttp"
+)
+
+const (
+	outputFormatMarkdown = "markdown"
+	outputFormatSwagger  = "swagger"
+)
+
+var errorsOutputFormat string
+
+var errorsCmd = &cobra.Command{
+	Use:   "errors",
+	Short: "Export the catalog of error codes clients can receive",
+	Long: `Export the catalog of error codes clients can receive, with their status, description and message.
+
+The swagger output is the responses section of the API docs, which the served docs already include.`,
+	Example: `  koogo errors > docs/ERRORS.md
+  koogo errors -o json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := koohttp.CheckErrorCatalog(); err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+
+		switch errorsOutputFormat {
+		case outputFormatMarkdown:
+			printErrorsMarkdown(out, koohttp.ErrorCatalog())
+			return nil
+		case outputFormatJSON:
+			return encoder.Encode(koohttp.ErrorCatalog())
+		case outputFormatSwagger:
+			return encoder.Encode(map[string]any{"responses": server.ErrorResponses()})
+		default:
+			return fmt.Errorf("unknown output format %q", errorsOutputFormat)
+		}
+	},
+}
+
+func init() {
+	errorsCmd.Flags().StringVarP(&errorsOutputFormat, "output", "o", outputFormatMarkdown, "Output format: markdown, json or swagger")
+
+	rootCmd.AddCommand(errorsCmd)
+}
+
+// printErrorsMarkdown prints the error catalog as a Markdown table, e.g. for client documentation.
+func printErrorsMarkdown(out io.Writer, definitions []koohttp.ErrorDefinition) {
+	cell := strings.NewReplacer("|", `\|`, "\n", " ")
+
+	fmt.Fprintln(out, "| Code | Status | Message | Description |")
+	fmt.Fprintln(out, "|------|--------|---------|-------------|")
+
+	for _, def := range definitions {
+		fmt.Fprintf(out, "| `%s` | %d | %s | %s |\n",
+			def.Code, def.Status, cell.Replace(def.Message), cell.Replace(def.Description))
+	}
+}
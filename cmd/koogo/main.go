@@ -33,12 +33,45 @@ var rootCmd = &cobra.Command{
 	Short: "Koogo is a production-ready Go API",
 }
 
+// Persistent flags shared by every command to locate configuration sources.
+var (
+	configFile string
+	envFile    string
+	secretsDir string
+	overrides  []string
+)
+
+// configOptions returns the config sources selected by the persistent flags.
+func configOptions(validate bool) config.Options {
+	opts := config.Options{
+		ConfigFile: configFile,
+		EnvFile:    envFile,
+		Overrides:  overrides,
+		Validate:   validate,
+	}
+
+	if secretsDir == "" {
+		secretsDir = os.Getenv("KOO_SECRETS_DIR")
+	}
+
+	if secretsDir != "" {
+		opts.SecretProvider = config.NewFileSecretProvider(secretsDir)
+	}
+
+	return opts
+}
+
+// loadConfig loads the config from all sources, see config.Options for their precedence.
+func loadConfig(ctx context.Context, validate bool) (*config.Config, error) {
+	return config.LoadConfig(ctx, configOptions(validate))
+}
+
 var startCmd = &cobra.Command{
 	Use:   "start",
 	Short: "Start the server",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Load config
-		cfg, err := config.LoadConfigFromEnv("", true)
+		cfg, err := loadConfig(cmd.Context(), true)
 		if err != nil {
 			return err
 		}
@@ -54,6 +87,11 @@ var startCmd = &cobra.Command{
 			return err
 		}
 
+		// Reload the runtime config on SIGHUP
+		go app.WatchReload(ctx, func(ctx context.Context) (*config.Config, error) {
+			return loadConfig(ctx, true)
+		})
+
 		// Start application (blocks until shutdown signal)
 		if err := app.Start(ctx); err != nil {
 			return err
@@ -69,6 +107,11 @@ var startCmd = &cobra.Command{
 }
 
 func init() {
+	rootCmd.PersistentFlags().StringVar(&configFile, "config", "", "Path to a YAML or TOML config file (default $KOO_CONFIG_FILE)")
+	rootCmd.PersistentFlags().StringVar(&envFile, "env-file", "", "Path to a dotenv file (default .env when KOO_APP_ENV is local)")
+	rootCmd.PersistentFlags().StringVar(&secretsDir, "secrets-dir", "", "Directory used to resolve secret://name values, one file per secret (default $KOO_SECRETS_DIR)")
+	rootCmd.PersistentFlags().StringArrayVar(&overrides, "set", nil, "Override a config value, e.g. --set db.host=localhost (repeatable)")
+
 	rootCmd.AddCommand(startCmd)
 
 	// Dynamically create job commands from JobsRegistry
@@ -78,7 +121,7 @@ func init() {
 			Short: "Run " + jobID + " job",
 			RunE: func(cmd *cobra.Command, args []string) error {
 				// Load config
-				cfg, err := config.LoadConfigFromEnv("", false)
+				cfg, err := loadConfig(cmd.Context(), false)
 				if err != nil {
 					return err
 				}
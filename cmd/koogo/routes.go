@@ -0,0 +1,110 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kootic/koogo/internal/server"
+)
+
+var routesOutputFormat string
+
+var routesCmd = &cobra.Command{
+	Use:   "routes",
+	Short: "List the API routes with their handler, middleware and metadata",
+	Long: `List the API routes with their handler, middleware and metadata.
+
+The route table is built from the route declarations without connecting to the database, so the
+output can be diffed between releases to review changes to the API surface.`,
+	Example: `  koogo routes
+  koogo routes -o json > routes.json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig(cmd.Context(), false)
+		if err != nil {
+			return err
+		}
+
+		entries, err := server.RouteTable(cfg)
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+
+		switch routesOutputFormat {
+		case outputFormatTable:
+			return printRoutesTable(out, entries)
+		case outputFormatJSON:
+			encoder := json.NewEncoder(out)
+			encoder.SetIndent("", "  ")
+
+			return encoder.Encode(entries)
+		default:
+			return fmt.Errorf("unknown output format %q", routesOutputFormat)
+		}
+	},
+}
+
+func init() {
+	routesCmd.Flags().StringVarP(&routesOutputFormat, "output", "o", outputFormatTable, "Output format: table or json")
+
+	rootCmd.AddCommand(routesCmd)
+}
+
+func printRoutesTable(out io.Writer, entries []server.RouteEntry) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "METHOD\tPATH\tNAME\tHANDLER\tMIDDLEWARE\tMETADATA")
+
+	for _, entry := range entries {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			entry.Method, entry.Path, entry.Name, entry.Handler,
+			strings.Join(entry.Middleware, ","), routeMetadata(entry.RouteInfo))
+	}
+
+	return w.Flush()
+}
+
+// routeMetadata formats the metadata of a route as space separated key=value pairs, omitting unset ones.
+func routeMetadata(info server.RouteInfo) string {
+	metadata := []string{"auth=" + string(info.Auth)}
+
+	if info.Introduced != info.Version {
+		metadata = append(metadata, fmt.Sprintf("introduced=v%d", info.Introduced))
+	}
+
+	if info.Supersedes != "" {
+		metadata = append(metadata, "supersedes="+info.Supersedes)
+	}
+
+	if info.SupersededBy != "" {
+		metadata = append(metadata, "supersededBy="+info.SupersededBy)
+	}
+
+	if len(info.Tags) > 0 {
+		metadata = append(metadata, "tags="+strings.Join(info.Tags, ","))
+	}
+
+	if info.RateLimit != nil {
+		metadata = append(metadata, fmt.Sprintf("rateLimit=%d/%s", info.RateLimit.Limit, info.RateLimit.Window))
+	}
+
+	if info.Timeout > 0 {
+		metadata = append(metadata, "timeout="+info.Timeout.String())
+	}
+
+	if info.BodyLimit > 0 {
+		metadata = append(metadata, fmt.Sprintf("bodyLimit=%d", info.BodyLimit))
+	}
+
+	if info.Deprecation != nil {
+		metadata = append(metadata, "deprecated="+info.Deprecation.Since.Format("2006-01-02"))
+	}
+
+	return strings.Join(metadata, " ")
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -14,105 +17,277 @@ import (
 
 	"github.com/kootic/koogo/internal/config"
 	"github.com/kootic/koogo/internal/server"
+	"github.com/kootic/koogo/pkg/kooctx"
 	"github.com/kootic/koogo/pkg/koodb"
+	"github.com/kootic/koogo/pkg/koohealth"
+	"github.com/kootic/koogo/pkg/koolifecycle"
 	"github.com/kootic/koogo/pkg/koolog"
 	"github.com/kootic/koogo/pkg/kootel"
+	"github.com/kootic/koogo/pkg/kooworker"
 )
 
+// Names of the built-in components, which components registered with App.Register can depend on.
+const (
+	ComponentOTel     = "otel"
+	ComponentDatabase = "database"
+	ComponentWorkers  = "workers"
+	ComponentServer   = "server"
+	ComponentAdmin    = "admin"
+)
+
+// Stop timeouts of the built-in components, bounded by the shutdown context.
+const (
+	otelStopTimeout     = 10 * time.Second
+	databaseStopTimeout = 5 * time.Second
+)
+
+// healthCheckCacheTTL is how long health check results are reused across probes.
+const healthCheckCacheTTL = 2 * time.Second
+
 // App represents the application and its dependencies.
 type App struct {
-	config       *config.Config
-	logger       *zap.Logger
-	fiberApp     *fiber.App
-	server       server.Server
-	cleanupFuncs []func(ctx context.Context) error
+	config    *config.Config
+	reloader  *config.Reloader
+	logger    *zap.Logger
+	logLevel  zap.AtomicLevel
+	fiberApp  *fiber.App
+	server    server.Server
+	sqlDB     *sql.DB
+	lifecycle *koolifecycle.Manager
+	checks    *koohealth.Registry
+	workers   *kooworker.Supervisor
 }
 
 // NewApp creates a new App instance.
 func NewApp(cfg *config.Config) *App {
 	return &App{
-		config: cfg,
+		config:    cfg,
+		reloader:  config.NewReloader(cfg),
+		lifecycle: koolifecycle.NewManager(),
+		checks:    koohealth.NewRegistry(healthCheckCacheTTL),
+		workers:   kooworker.NewSupervisor(kooworker.Backoff{}),
+	}
+}
+
+// Register adds a component, such as a cache or a queue consumer, to the application lifecycle.
+// It must be called before Bootstrap. Components are started during Bootstrap after their dependencies
+// and stopped in reverse order during Shutdown.
+func (a *App) Register(component koolifecycle.Component) error {
+	return a.lifecycle.Register(component)
+}
+
+// RegisterHealthCheck adds a check, such as a cache or an outbound dependency, to the health report
+// served by /api/v1/health and /readyz.
+func (a *App) RegisterHealthCheck(check koohealth.Check) error {
+	return a.checks.Register(check)
+}
+
+// RegisterWorker adds a background worker, such as a queue consumer, that is started by Start,
+// restarted with backoff when it fails and stopped during Shutdown after the server has drained.
+// It must be called before Bootstrap. The worker is reported by a non-critical health check.
+func (a *App) RegisterWorker(worker kooworker.Worker) error {
+	if err := a.workers.Add(worker); err != nil {
+		return err
 	}
+
+	return a.checks.Register(koohealth.Check{
+		Name:  "worker:" + worker.Name(),
+		Check: a.workers.HealthCheck(worker.Name()),
+	})
 }
 
 // Bootstrap initializes the application and its dependencies.
 func (a *App) Bootstrap(ctx context.Context) error {
-	// Initialize logger
-	logger, err := koolog.NewLogger(a.config.App.IsProd(), a.config.App.ZapLogLevel())
+	// Initialize logger, the level follows the runtime config so it can be changed on reload
+	a.logLevel = zap.NewAtomicLevelAt(a.config.Runtime.ZapLogLevel())
+
+	logger, err := koolog.NewLogger(a.config.App.IsProd(), a.logLevel)
 	if err != nil {
 		return fmt.Errorf("failed to initialize logger: %w", err)
 	}
 
-	// Initialize OpenTelemetry
-	stop, err := kootel.InitializeOTel(ctx, kootel.OTelConfig{
-		ServiceName:    a.config.App.Name,
-		ServiceVersion: a.config.App.Version,
-		Environment:    string(a.config.App.Env),
-		ExporterType:   a.config.OTel.Exporter,
+	a.logger = logger
+
+	a.reloader.Subscribe(func(runtime config.RuntimeConfig) {
+		a.logLevel.SetLevel(runtime.ZapLogLevel())
 	})
-	if err != nil {
-		return fmt.Errorf("failed to initialize OpenTelemetry: %w", err)
-	}
-
-	a.cleanupFuncs = append(a.cleanupFuncs, stop)
-
-	a.logger = zap.New(
-		zapcore.NewTee(
-			logger.Core(),
-			otelzap.NewCore(a.config.App.Name, otelzap.WithLoggerProvider(global.GetLoggerProvider())),
-		),
-		zap.AddStacktrace(zapcore.ErrorLevel),
-	)
-
-	// Initialize database client
-	var sqldb *sql.DB
-	if a.config.App.IsTest() {
-		sqldb, err = koodb.NewPostgresTxDB(a.config.Database.DSN())
-	} else {
-		poolConfig := &koodb.PoolConfig{
-			MaxConns:          a.config.Database.MaxConns,
-			MinConns:          a.config.Database.MinConns,
-			MaxConnLifetime:   time.Duration(a.config.Database.MaxConnLifetime) * time.Minute,
-			MaxConnIdleTime:   time.Duration(a.config.Database.MaxConnIdleTime) * time.Minute,
-			ConnectionTimeout: time.Duration(a.config.Database.ConnectionTimeout) * time.Second,
+
+	components := []koolifecycle.Component{
+		a.otelComponent(logger),
+		a.databaseComponent(),
+		a.workersComponent(),
+		a.serverComponent(),
+	}
+	if a.config.App.AdminEnabled() {
+		components = append(components, a.adminComponent())
+	}
+
+	for _, component := range components {
+		if err := a.lifecycle.Register(component); err != nil {
+			return fmt.Errorf("failed to register component: %w", err)
 		}
-		sqldb, err = koodb.NewPostgresPool(ctx, a.config.Database.DSN(), poolConfig)
 	}
 
-	if err != nil {
-		return fmt.Errorf("failed to create database pool: %w", err)
+	if err := a.lifecycle.Start(kooctx.SetContextLogger(ctx, a.logger)); err != nil {
+		return fmt.Errorf("failed to start components: %w", err)
 	}
 
-	a.cleanupFuncs = append(a.cleanupFuncs, func(ctx context.Context) error {
-		return sqldb.Close()
-	})
+	return nil
+}
 
-	// Create server with fiber app
-	a.fiberApp = fiber.New(fiber.Config{
-		ReadTimeout:  time.Duration(a.config.App.ReadTimeout) * time.Second,
-		WriteTimeout: time.Duration(a.config.App.WriteTimeout) * time.Second,
-		IdleTimeout:  time.Duration(a.config.App.IdleTimeout) * time.Second,
-		BodyLimit:    a.config.App.BodyLimit * 1024 * 1024, // Convert MB to bytes
-	})
+// otelComponent initializes OpenTelemetry and tees logs to the OTel logger provider.
+func (a *App) otelComponent(logger *zap.Logger) koolifecycle.Component {
+	var stop func(ctx context.Context) error
 
-	srv, err := server.NewServer(a.config, a.logger, sqldb, a.fiberApp)
-	if err != nil {
-		return fmt.Errorf("failed to create server: %w", err)
+	return koolifecycle.Component{
+		Name: ComponentOTel,
+		Start: func(ctx context.Context) error {
+			var err error
+
+			stop, err = kootel.InitializeOTel(ctx, kootel.OTelConfig{
+				ServiceName:    a.config.App.Name,
+				ServiceVersion: a.config.App.Version,
+				Environment:    string(a.config.App.Env),
+				ExporterType:   a.config.OTel.Exporter,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to initialize OpenTelemetry: %w", err)
+			}
+
+			if a.config.OTel.Exporter == kootel.OTelExporterTypeOTLPgRPC {
+				err = a.checks.Register(koohealth.Check{
+					Name:  "otel",
+					Check: kootel.CheckExport,
+				})
+				if err != nil {
+					return fmt.Errorf("failed to register health check: %w", err)
+				}
+			}
+
+			a.logger = zap.New(
+				zapcore.NewTee(
+					logger.Core(),
+					otelzap.NewCore(a.config.App.Name, otelzap.WithLoggerProvider(global.GetLoggerProvider())),
+				),
+				zap.AddStacktrace(zapcore.ErrorLevel),
+			)
+
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			return stop(ctx)
+		},
+		StopTimeout: otelStopTimeout,
 	}
+}
 
-	a.server = srv
+// databaseComponent opens the database pool, or a single transaction per connection in tests.
+func (a *App) databaseComponent() koolifecycle.Component {
+	return koolifecycle.Component{
+		Name:      ComponentDatabase,
+		DependsOn: []string{ComponentOTel},
+		Start: func(ctx context.Context) error {
+			var err error
 
-	// Initialize fiber app
-	err = a.server.Initialize()
-	if err != nil {
-		return fmt.Errorf("failed to initialize fiber app: %w", err)
+			if a.config.App.IsTest() {
+				a.sqlDB, err = koodb.NewPostgresTxDB(a.config.Database.DSN())
+			} else {
+				poolConfig := &koodb.PoolConfig{
+					MaxConns:          a.config.Database.MaxConns,
+					MinConns:          a.config.Database.MinConns,
+					MaxConnLifetime:   time.Duration(a.config.Database.MaxConnLifetime) * time.Minute,
+					MaxConnIdleTime:   time.Duration(a.config.Database.MaxConnIdleTime) * time.Minute,
+					ConnectionTimeout: time.Duration(a.config.Database.ConnectionTimeout) * time.Second,
+				}
+				a.sqlDB, err = koodb.NewPostgresPool(ctx, a.config.Database.DSN(), poolConfig)
+			}
+
+			if err != nil {
+				return fmt.Errorf("failed to create database pool: %w", err)
+			}
+
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			return a.sqlDB.Close()
+		},
+		StopTimeout: databaseStopTimeout,
 	}
+}
 
-	return nil
+// workersComponent stops the background workers started by Start. The server depends on it, so workers
+// keep running until the server has drained and stop before the database is closed.
+func (a *App) workersComponent() koolifecycle.Component {
+	return koolifecycle.Component{
+		Name:      ComponentWorkers,
+		DependsOn: []string{ComponentOTel, ComponentDatabase},
+		Stop:      a.workers.Stop,
+	}
+}
+
+// serverComponent creates and initializes the HTTP server, which is started by Start and drained on stop.
+func (a *App) serverComponent() koolifecycle.Component {
+	return koolifecycle.Component{
+		Name: ComponentServer,
+		// Workers stop after the server has drained, whatever order the components are registered in
+		DependsOn: []string{ComponentOTel, ComponentDatabase, ComponentWorkers},
+		Start: func(ctx context.Context) error {
+			// Create server with fiber app
+			a.fiberApp = fiber.New(fiber.Config{
+				ReadTimeout:  time.Duration(a.config.App.ReadTimeout) * time.Second,
+				WriteTimeout: time.Duration(a.config.App.WriteTimeout) * time.Second,
+				IdleTimeout:  time.Duration(a.config.App.IdleTimeout) * time.Second,
+				BodyLimit:    a.config.App.BodyLimit * 1024 * 1024, // Convert MB to bytes
+			})
+
+			srv, err := server.NewServer(a.config, a.reloader, a.checks, a.logger, a.sqlDB, a.fiberApp)
+			if err != nil {
+				return fmt.Errorf("failed to create server: %w", err)
+			}
+
+			// Initialize fiber app
+			if err := srv.Initialize(); err != nil {
+				return fmt.Errorf("failed to initialize fiber app: %w", err)
+			}
+
+			a.server = srv
+
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			if err := a.server.Shutdown(ctx); err != nil {
+				return fmt.Errorf("failed to shutdown server: %w", err)
+			}
+
+			a.logger.Info("Server shutdown complete")
+
+			return nil
+		},
+	}
+}
+
+// adminComponent serves the internal admin server on its own port, see server.AdminServer.
+func (a *App) adminComponent() koolifecycle.Component {
+	var admin *server.AdminServer
+
+	return koolifecycle.Component{
+		Name:      ComponentAdmin,
+		DependsOn: []string{ComponentServer},
+		Start: func(ctx context.Context) error {
+			admin = server.NewAdminServer(a.config, a.reloader, a.logLevel, a.server.Routes(), a.logger)
+			return admin.Start(ctx)
+		},
+		Stop: func(ctx context.Context) error {
+			return admin.Shutdown(ctx)
+		},
+	}
 }
 
 // Start starts the application and blocks until shutdown signal is received.
 func (a *App) Start(ctx context.Context) error {
+	// Start background workers, they are stopped by Shutdown rather than by cancelling ctx
+	a.workers.Start(kooctx.SetContextLogger(ctx, a.logger))
+
 	// Start server in a goroutine
 	serverErr := make(chan error, 1)
 	go func() {
@@ -133,24 +308,52 @@ func (a *App) Start(ctx context.Context) error {
 	}
 }
 
-// Shutdown gracefully shuts down the application.
-func (a *App) Shutdown(ctx context.Context) error {
-	a.logger.Info("Initiating graceful shutdown...")
+// WatchReload reloads the runtime config with load whenever the process receives SIGHUP, until ctx is done.
+// Invalid configs are rejected and the current settings are kept.
+func (a *App) WatchReload(ctx context.Context, load func(ctx context.Context) (*config.Config, error)) {
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+
+	defer signal.Stop(sighup)
 
-	if a.server != nil {
-		if err := a.server.Shutdown(ctx); err != nil {
-			a.logger.Error("Failed to shutdown server", zap.Error(err))
-			return fmt.Errorf("failed to shutdown server: %w", err)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sighup:
+			a.Reload(ctx, load)
 		}
+	}
+}
+
+// Reload loads and validates the config with load and applies its runtime section.
+func (a *App) Reload(ctx context.Context, load func(ctx context.Context) (*config.Config, error)) {
+	a.logger.Info("Reloading config...")
 
-		a.logger.Info("Server shutdown complete")
+	cfg, err := load(ctx)
+	if err != nil {
+		a.logger.Error("Failed to reload config, keeping current settings", zap.Error(err))
+		return
 	}
 
-	for i, fn := range a.cleanupFuncs {
-		if err := fn(ctx); err != nil {
-			a.logger.Error("Failed to cleanup app", zap.Error(err), zap.Int("cleanup_index", i))
-			return fmt.Errorf("failed to cleanup: %w", err)
-		}
+	for _, key := range a.reloader.Apply(cfg) {
+		a.logger.Warn("Config change requires a restart to take effect", zap.String("key", key))
+	}
+
+	a.logger.Info("Config reloaded successfully", zap.Any("runtime", a.reloader.Runtime()))
+}
+
+// Shutdown gracefully shuts down the application, stopping every started component in reverse order.
+// All components are stopped even when some fail, and their errors are returned together.
+func (a *App) Shutdown(ctx context.Context) error {
+	if a.logger == nil {
+		return nil
+	}
+
+	a.logger.Info("Initiating graceful shutdown...")
+
+	if err := a.lifecycle.Stop(kooctx.SetContextLogger(ctx, a.logger)); err != nil {
+		return fmt.Errorf("failed to shutdown: %w", err)
 	}
 
 	a.logger.Info("Application shutdown successfully")
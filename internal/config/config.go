@@ -2,13 +2,17 @@ package config
 
 import (
 	"fmt"
-	"log"
-	"os"
+	"net"
+	"net/url"
+	"path"
 	"strconv"
+	"time"
 
-	"github.com/joho/godotenv"
 	"go.uber.org/zap/zapcore"
 
+	"github.com/kootic/koogo/pkg/koodb"
+	"github.com/kootic/koogo/pkg/kooflag"
+	"github.com/kootic/koogo/pkg/koohttp"
 	"github.com/kootic/koogo/pkg/kootel"
 )
 
@@ -39,55 +43,139 @@ const (
 	AppLogLevelError AppLogLevel = "error"
 )
 
+var validLogLevels = map[AppLogLevel]bool{
+	AppLogLevelDebug: true,
+	AppLogLevelInfo:  true,
+	AppLogLevelWarn:  true,
+	AppLogLevelError: true,
+}
+
+var validSSLModes = map[string]bool{
+	"disable":     true,
+	"allow":       true,
+	"prefer":      true,
+	"require":     true,
+	"verify-ca":   true,
+	"verify-full": true,
+}
+
+var validDBURLSchemes = map[string]bool{
+	"postgres":   true,
+	"postgresql": true,
+}
+
+type FeatureFlagStore string
+
+const (
+	FeatureFlagStoreConfig   FeatureFlagStore = "config"
+	FeatureFlagStorePostgres FeatureFlagStore = "postgres"
+)
+
+var validFeatureFlagStores = map[FeatureFlagStore]bool{
+	FeatureFlagStoreConfig:   true,
+	FeatureFlagStorePostgres: true,
+}
+
+var validErrorFormats = map[koohttp.ErrorFormat]bool{
+	koohttp.ErrorFormatLegacy:  true,
+	koohttp.ErrorFormatProblem: true,
+}
+
+var validOTelExporters = map[kootel.OTelExporterType]bool{
+	kootel.OTelExporterTypeConsole:  true,
+	kootel.OTelExporterTypeOTLPgRPC: true,
+	kootel.OTelExporterTypeNoop:     true,
+}
+
 type Config struct {
 	App      AppConfig
+	Runtime  RuntimeConfig
 	Swagger  SwaggerConfig
 	OTel     OTelConfig
 	Database DatabaseConfig
 }
 
+// Validate checks every section and returns FieldErrors listing all invalid fields, joined with any other
+// errors of the sections, or nil.
 func (c *Config) Validate() error {
-	if err := c.App.Validate(); err != nil {
-		return fmt.Errorf("app config is invalid: %w", err)
-	}
+	var v validator
 
-	if err := c.Swagger.Validate(); err != nil {
-		return fmt.Errorf("swagger config is invalid: %w", err)
+	for _, section := range []interface{ Validate() error }{&c.App, &c.Runtime, &c.Swagger, &c.OTel, &c.Database} {
+		v.add(section.Validate())
 	}
 
-	if err := c.OTel.Validate(); err != nil {
-		return fmt.Errorf("otel config is invalid: %w", err)
-	}
-
-	if err := c.Database.Validate(); err != nil {
-		return fmt.Errorf("database config is invalid: %w", err)
-	}
-
-	return nil
+	return v.err()
 }
 
 type AppConfig struct {
-	Name         string
-	Version      string
-	Env          AppEnv
-	Port         int
-	LogLevel     AppLogLevel
-	ReadTimeout  int // Read timeout in seconds
-	WriteTimeout int // Write timeout in seconds
-	IdleTimeout  int // Idle timeout in seconds
-	BodyLimit    int // Body limit in megabytes
+	Name             string              `env:"KOO_APP_NAME"                  required:"true"`
+	Version          string              `env:"KOO_APP_VERSION"               required:"true"`
+	Env              AppEnv              `env:"KOO_APP_ENV"                   default:"local"`
+	Port             int                 `env:"KOO_APP_PORT"`
+	ReadTimeout      int                 `env:"KOO_APP_READ_TIMEOUT_SECONDS"  default:"15"`        // Read timeout in seconds
+	WriteTimeout     int                 `env:"KOO_APP_WRITE_TIMEOUT_SECONDS" default:"15"`        // Write timeout in seconds
+	IdleTimeout      int                 `env:"KOO_APP_IDLE_TIMEOUT_SECONDS"  default:"120"`       // Idle timeout in seconds
+	BodyLimit        int                 `env:"KOO_APP_BODY_LIMIT_MB"         default:"4"`         // Body limit in megabytes
+	DrainDelay       time.Duration       `env:"KOO_APP_DRAIN_DELAY"           default:"0s"`        // Time to keep serving after readiness fails on shutdown
+	AdminHost        string              `env:"KOO_APP_ADMIN_HOST"            default:"127.0.0.1"` // Interface the admin server binds to, 0.0.0.0 binds every interface
+	AdminPort        int                 `env:"KOO_APP_ADMIN_PORT"            default:"0"`         // Port of the internal admin server, 0 disables it
+	AdminUsername    string              `env:"KOO_APP_ADMIN_USERNAME"`
+	AdminPassword    Secret              `env:"KOO_APP_ADMIN_PASSWORD"`
+	Socket           string              `env:"KOO_APP_SOCKET"`                               // Unix socket path to listen on instead of KOO_APP_PORT, e.g. behind a sidecar proxy
+	TLSCert          string              `env:"KOO_APP_TLS_CERT"`                             // Path of the PEM certificate, enables TLS and is reloaded when it changes
+	TLSKey           string              `env:"KOO_APP_TLS_KEY"`                              // Path of the PEM private key
+	TLSClientCA      string              `env:"KOO_APP_TLS_CLIENT_CA"`                        // Path of the PEM CA bundle used to verify client certificates, enables mutual TLS
+	ErrorFormat      koohttp.ErrorFormat `env:"KOO_APP_ERROR_FORMAT"        default:"legacy"` // Representation of error responses, clients accepting application/problem+json always get problem details
+	ErrorTypeBaseURL string              `env:"KOO_APP_ERROR_TYPE_BASE_URL"`                  // Base of problem details type URIs, e.g. https://example.com/errors/, about:blank when unset
 }
 
 func (a *AppConfig) Validate() error {
-	if a.Name == "" || a.Version == "" || a.Env == "" || a.Port == 0 || a.LogLevel == "" {
-		return fmt.Errorf("app config is incomplete")
+	v := newValidator("App", a)
+	v.required(a.Name, "Name")
+	v.required(a.Version, "Version")
+	v.check(validEnvs[a.Env], "Env", fmt.Sprintf("invalid app env %q", a.Env))
+
+	if a.Socket == "" {
+		v.port(a.Port, "Port")
+	}
+
+	if a.TLSEnabled() || a.TLSKey != "" {
+		v.required(a.TLSCert, "TLSCert")
+		v.required(a.TLSKey, "TLSKey")
+	}
+
+	v.check(a.TLSClientCA == "" || a.TLSEnabled(), "TLSClientCA", "requires KOO_APP_TLS_CERT and KOO_APP_TLS_KEY")
+
+	v.positive(a.ReadTimeout, "ReadTimeout")
+	v.positive(a.WriteTimeout, "WriteTimeout")
+	v.positive(a.IdleTimeout, "IdleTimeout")
+	v.positive(a.BodyLimit, "BodyLimit")
+	v.check(a.DrainDelay >= 0, "DrainDelay", "must not be negative")
+	v.check(validErrorFormats[a.ErrorFormat], "ErrorFormat", fmt.Sprintf("invalid error format %q", a.ErrorFormat))
+
+	if a.ErrorTypeBaseURL != "" {
+		u, err := url.Parse(a.ErrorTypeBaseURL)
+		v.check(err == nil && u.IsAbs(), "ErrorTypeBaseURL", "must be an absolute URL")
 	}
 
-	if !validEnvs[a.Env] {
-		return fmt.Errorf("invalid app env: %s", a.Env)
+	if a.AdminEnabled() {
+		v.port(a.AdminPort, "AdminPort")
+		v.compare(a.AdminPort != a.Port, "AdminPort", "Port", "must differ from KOO_APP_PORT")
+		v.required(a.AdminUsername, "AdminUsername")
+		v.required(a.AdminPassword, "AdminPassword")
 	}
 
-	return nil
+	return v.err()
+}
+
+// TLSEnabled reports whether the server listens with TLS.
+func (a *AppConfig) TLSEnabled() bool {
+	return a.TLSCert != ""
+}
+
+// AdminEnabled reports whether the internal admin server is enabled.
+func (a *AppConfig) AdminEnabled() bool {
+	return a.AdminPort != 0
 }
 
 func (a *AppConfig) IsProd() bool {
@@ -98,8 +186,31 @@ func (a *AppConfig) IsTest() bool {
 	return a.Env == AppEnvTest
 }
 
-func (a *AppConfig) ZapLogLevel() zapcore.Level {
-	switch a.LogLevel {
+// RuntimeConfig holds the settings that can be changed without restarting the process, see Reloader.
+type RuntimeConfig struct {
+	LogLevel         AppLogLevel   `env:"KOO_APP_LOG_LEVEL"      required:"true"`
+	LogRequests      bool          `env:"KOO_LOG_REQUESTS"       default:"true"` // Log every request, otherwise only server errors
+	LogRequestBodies bool          `env:"KOO_LOG_REQUEST_BODIES" default:"true"` // Include request and response bodies in request logs
+	RateLimit        int           `env:"KOO_RATE_LIMIT"         default:"0"`    // Maximum requests per client IP within RateLimitWindow, 0 disables rate limiting
+	RateLimitWindow  time.Duration `env:"KOO_RATE_LIMIT_WINDOW"  default:"1m"`
+	RequestLog       RequestLogConfig
+	FeatureFlags     FeatureFlagsConfig
+}
+
+func (r *RuntimeConfig) Validate() error {
+	v := newValidator("Runtime", r)
+	v.check(validLogLevels[r.LogLevel], "LogLevel", fmt.Sprintf("invalid log level %q", r.LogLevel))
+	v.check(r.RateLimit >= 0, "RateLimit", "must not be negative")
+	v.check(r.RateLimitWindow >= time.Second, "RateLimitWindow", "must be at least 1s")
+
+	v.add(r.RequestLog.Validate())
+	v.add(r.FeatureFlags.Validate())
+
+	return v.err()
+}
+
+func (r *RuntimeConfig) ZapLogLevel() zapcore.Level {
+	switch r.LogLevel {
 	case AppLogLevelDebug:
 		return zapcore.DebugLevel
 	case AppLogLevelInfo:
@@ -113,202 +224,207 @@ func (a *AppConfig) ZapLogLevel() zapcore.Level {
 	}
 }
 
-type SwaggerConfig struct {
-	Enabled  bool
-	Username string
-	Password string
+// RequestLogConfig controls what the request log contains, so that credentials and personal data in
+// headers and bodies are not logged.
+type RequestLogConfig struct {
+	RedactHeaders     []string `env:"KOO_LOG_REDACT_HEADERS"      default:"Authorization,Proxy-Authorization,Cookie,Set-Cookie,X-Api-Key"` // Headers logged as [REDACTED], case-insensitive
+	RedactFields      []string `env:"KOO_LOG_REDACT_FIELDS"       default:"password,secret,token,apiKey,api_key"`                          // JSON body fields whose name contains any of these are logged as [REDACTED], case-insensitive
+	BodyMaxBytes      int      `env:"KOO_LOG_BODY_MAX_BYTES"      default:"4096"`                                                          // Bodies are truncated to this size, 0 disables the limit
+	BodyContentTypes  []string `env:"KOO_LOG_BODY_CONTENT_TYPES"  default:"application/json,text/plain"`                                   // Only bodies of these content types are logged, e.g. text/*, other bodies are omitted
+	SkipPaths         []string `env:"KOO_LOG_SKIP_PATHS"          default:"/api/health,/api/v*/health"`                                    // Path patterns of requests that are not logged, see path.Match
+	SuccessSampleRate float64  `env:"KOO_LOG_SUCCESS_SAMPLE_RATE" default:"1"`                                                             // Fraction of requests with a status below 400 that are logged, from 0 to 1
 }
 
-func (s *SwaggerConfig) Validate() error {
-	if s.Enabled && (s.Username == "" || s.Password == "") {
-		return fmt.Errorf("swagger config is incomplete")
-	}
+func (l *RequestLogConfig) Validate() error {
+	v := newValidator("Runtime.RequestLog", l)
+	v.check(l.BodyMaxBytes >= 0, "BodyMaxBytes", "must not be negative")
+	v.check(l.SuccessSampleRate >= 0 && l.SuccessSampleRate <= 1, "SuccessSampleRate", "must be between 0 and 1")
 
-	return nil
-}
-
-type OTelConfig struct {
-	Enabled  bool
-	Exporter kootel.OTelExporterType
-}
+	for _, pattern := range l.BodyContentTypes {
+		_, err := path.Match(pattern, "")
+		v.check(err == nil, "BodyContentTypes", fmt.Sprintf("invalid content type pattern %q", pattern))
+	}
 
-func (o *OTelConfig) Validate() error {
-	if o.Enabled && o.Exporter == "" {
-		return fmt.Errorf("otel config is incomplete")
+	for _, pattern := range l.SkipPaths {
+		_, err := path.Match(pattern, "")
+		v.check(err == nil, "SkipPaths", fmt.Sprintf("invalid path pattern %q", pattern))
 	}
 
-	return nil
+	return v.err()
 }
 
-type DatabaseConfig struct {
-	Host              string
-	Port              int
-	Username          string
-	Password          string
-	Database          string
-	MaxConns          int    // Maximum number of connections in the pool
-	MinConns          int    // Minimum number of connections in the pool
-	MaxConnLifetime   int    // Maximum lifetime of a connection in minutes
-	MaxConnIdleTime   int    // Maximum idle time of a connection in minutes
-	ConnectionTimeout int    // Connection timeout in seconds
-	SSLMode           string // SSL mode for the database connection
+// FeatureFlagsConfig selects where feature flags are read from, see kooflag.
+type FeatureFlagsConfig struct {
+	Store    FeatureFlagStore `env:"KOO_FEATURE_FLAGS_STORE"     default:"config"`
+	Enabled  []string         `env:"KOO_FEATURE_FLAGS"`                         // Flags enabled for everyone, config store only
+	Path     string           `env:"KOO_FEATURE_FLAGS_PATH"`                    // YAML or JSON file with flag definitions and rules, config store only
+	CacheTTL time.Duration    `env:"KOO_FEATURE_FLAGS_CACHE_TTL" default:"30s"` // How long flags read from the postgres store are cached
 }
 
-func (d *DatabaseConfig) Validate() error {
-	if d.Host == "" || d.Port == 0 || d.Username == "" || d.Password == "" {
-		return fmt.Errorf("database config is incomplete")
-	}
-
-	return nil
-}
+func (f *FeatureFlagsConfig) Validate() error {
+	v := newValidator("Runtime.FeatureFlags", f)
+	v.check(validFeatureFlagStores[f.Store], "Store", fmt.Sprintf("invalid feature flag store %q", f.Store))
+	v.check(f.CacheTTL >= 0, "CacheTTL", "must not be negative")
 
-func (d *DatabaseConfig) DSN() string {
-	dsn := fmt.Sprintf("postgres://%s:%s@%s:%d/%s", d.Username, d.Password, d.Host, d.Port, d.Database)
-	if d.SSLMode != "" {
-		dsn += "?sslmode=" + d.SSLMode
+	if f.Path != "" {
+		_, err := kooflag.LoadFile(f.Path)
+		v.check(err == nil, "Path", fmt.Sprintf("invalid feature flag file: %v", err))
 	}
 
-	return dsn
+	return v.err()
 }
 
-func (d *DatabaseConfig) DSNWithoutDatabase() string {
-	dsn := fmt.Sprintf("postgres://%s:%s@%s:%d", d.Username, d.Password, d.Host, d.Port)
-	if d.SSLMode != "" {
-		dsn += "?sslmode=" + d.SSLMode
-	}
+// Flags returns the flags defined by the config store, flags listed in Enabled override
+// definitions from Path and are enabled for everyone.
+func (f *FeatureFlagsConfig) Flags() ([]kooflag.Flag, error) {
+	var flags []kooflag.Flag
 
-	return dsn
-}
+	if f.Path != "" {
+		fileFlags, err := kooflag.LoadFile(f.Path)
+		if err != nil {
+			return nil, err
+		}
 
-// getEnvAsInt parses an environment variable as int with a default value.
-func getEnvAsInt(key string, defaultValue int) int {
-	value := os.Getenv(key)
-	if value == "" {
-		return defaultValue
+		flags = append(flags, fileFlags...)
 	}
 
-	intValue, err := strconv.Atoi(value)
-	if err != nil {
-		return defaultValue
+	for _, key := range f.Enabled {
+		flags = append(flags, kooflag.Flag{Key: key, Enabled: true})
 	}
 
-	return intValue
+	return flags, nil
+}
+
+type SwaggerConfig struct {
+	Enabled  bool   `env:"KOO_SWAGGER_ENABLED"  default:"false"`
+	Username string `env:"KOO_SWAGGER_USERNAME"`
+	Password Secret `env:"KOO_SWAGGER_PASSWORD"`
 }
 
-// getEnvAsString parses an environment variable as string with a default value.
-func getEnvAsString(key string, defaultValue string) string {
-	value := os.Getenv(key)
-	if value == "" {
-		return defaultValue
+func (s *SwaggerConfig) Validate() error {
+	v := newValidator("Swagger", s)
+	if s.Enabled {
+		v.required(s.Username, "Username")
+		v.required(s.Password, "Password")
 	}
 
-	return value
+	return v.err()
 }
 
-// getEnvAsBool parses an environment variable as boolean with a default value.
-func getEnvAsBool(key string, defaultValue bool) bool {
-	value := os.Getenv(key)
-	if value == "" {
-		return defaultValue
+type OTelConfig struct {
+	Enabled  bool                    `env:"KOO_OTEL_ENABLED"  default:"false"`
+	Exporter kootel.OTelExporterType `env:"KOO_OTEL_EXPORTER"`
+}
+
+func (o *OTelConfig) Validate() error {
+	v := newValidator("OTel", o)
+	if o.Enabled {
+		v.required(o.Exporter, "Exporter")
 	}
 
-	boolValue, err := strconv.ParseBool(value)
-	if err != nil {
-		return defaultValue
+	if o.Exporter != "" {
+		v.check(validOTelExporters[o.Exporter], "Exporter", fmt.Sprintf("invalid exporter %q", o.Exporter))
 	}
 
-	return boolValue
+	return v.err()
 }
 
-// LoadConfigFromEnv prepares the config from environment variables. When not running locally,
-// we must set KOO_APP_ENV to a valid environment. If KOO_APP_ENV is not set or is set to "local",
-// we will load the .env file at envFilePath or simply use the .env file in the same directory as the main.go file.
-func LoadConfigFromEnv(envFilePath string, validate bool) (*Config, error) {
-	appEnv := AppEnv(getEnvAsString("KOO_APP_ENV", string(AppEnvLocal)))
-
-	// This is only for local development, env files will not be included in the build and we rely on environment variables
-	if appEnv == AppEnvLocal {
-		if envFilePath == "" {
-			envFilePath = ".env"
-		}
+type DatabaseConfig struct {
+	URL                Secret        `env:"KOO_DB_URL"` // Full connection URL, replaces the host, port, credentials and database below
+	Host               string        `env:"KOO_DB_HOST"`
+	Port               int           `env:"KOO_DB_PORT"                       default:"5432"`
+	Username           string        `env:"KOO_DB_USERNAME"`
+	Password           Secret        `env:"KOO_DB_PASSWORD"`
+	Database           string        `env:"KOO_DB_DATABASE"`
+	SSLMode            string        `env:"KOO_DB_SSL_MODE"`                                // SSL mode for the database connection
+	SSLRootCert        string        `env:"KOO_DB_SSL_ROOT_CERT"`                           // Path to the CA certificate used to verify the server
+	ApplicationName    string        `env:"KOO_DB_APPLICATION_NAME"`                        // Reported in pg_stat_activity
+	SearchPath         string        `env:"KOO_DB_SEARCH_PATH"`                             // Comma separated list of schemas
+	StatementTimeout   time.Duration `env:"KOO_DB_STATEMENT_TIMEOUT"`                       // e.g. 30s, zero keeps the server default
+	TargetSessionAttrs string        `env:"KOO_DB_TARGET_SESSION_ATTRS"`                    // e.g. read-write, primary
+	MaxConns           int           `env:"KOO_DB_MAX_CONNS"                  default:"25"` // Maximum number of connections in the pool
+	MinConns           int           `env:"KOO_DB_MIN_CONNS"                  default:"5"`  // Minimum number of connections in the pool
+	MaxConnLifetime    int           `env:"KOO_DB_MAX_CONN_LIFETIME_MINUTES"  default:"60"` // Maximum lifetime of a connection in minutes
+	MaxConnIdleTime    int           `env:"KOO_DB_MAX_CONN_IDLE_TIME_MINUTES" default:"30"` // Maximum idle time of a connection in minutes
+	ConnectionTimeout  int           `env:"KOO_DB_CONNECTION_TIMEOUT_SECONDS" default:"10"` // Connection timeout in seconds
+}
 
-		if err := godotenv.Load(envFilePath); err != nil {
-			log.Println("Unable to load .env file, using environment variables only; error:", err)
-		}
+func (d *DatabaseConfig) Validate() error {
+	v := newValidator("Database", d)
+
+	if d.URL != "" {
+		u, err := url.Parse(d.URL.Reveal())
+		v.check(err == nil && validDBURLSchemes[u.Scheme] && u.Host != "", "URL", "must be a postgres:// or postgresql:// URL")
+	} else {
+		v.required(d.Host, "Host")
+		v.port(d.Port, "Port")
+		v.required(d.Username, "Username")
+		v.required(d.Password, "Password")
 	}
 
-	var err error
+	if d.SSLMode != "" {
+		v.check(validSSLModes[d.SSLMode], "SSLMode", fmt.Sprintf("invalid ssl mode %q", d.SSLMode))
+	}
 
-	var appPort int
+	v.check(d.StatementTimeout >= 0, "StatementTimeout", "must not be negative")
+	v.positive(d.MaxConns, "MaxConns")
+	v.check(d.MinConns >= 0, "MinConns", "must not be negative")
+	v.compare(d.MinConns <= d.MaxConns, "MinConns", "MaxConns", fmt.Sprintf("must not exceed KOO_DB_MAX_CONNS (%d)", d.MaxConns))
+	v.positive(d.MaxConnLifetime, "MaxConnLifetime")
+	v.positive(d.MaxConnIdleTime, "MaxConnIdleTime")
+	v.positive(d.ConnectionTimeout, "ConnectionTimeout")
 
-	appPort, err = strconv.Atoi(os.Getenv("KOO_APP_PORT"))
-	if validate && err != nil {
-		return nil, fmt.Errorf("invalid app port: %w", err)
-	}
+	return v.err()
+}
 
-	appConfig := AppConfig{
-		Name:         os.Getenv("KOO_APP_NAME"),
-		Version:      os.Getenv("KOO_APP_VERSION"),
-		Env:          appEnv,
-		Port:         appPort,
-		LogLevel:     AppLogLevel(os.Getenv("KOO_APP_LOG_LEVEL")),
-		ReadTimeout:  getEnvAsInt("KOO_APP_READ_TIMEOUT_SECONDS", 15),
-		WriteTimeout: getEnvAsInt("KOO_APP_WRITE_TIMEOUT_SECONDS", 15),
-		IdleTimeout:  getEnvAsInt("KOO_APP_IDLE_TIMEOUT_SECONDS", 120),
-		BodyLimit:    getEnvAsInt("KOO_APP_BODY_LIMIT_MB", 4),
+// ConnParams returns the optional connection parameters that are encoded into the DSN.
+func (d *DatabaseConfig) ConnParams() koodb.ConnParams {
+	return koodb.ConnParams{
+		SSLMode:            d.SSLMode,
+		SSLRootCert:        d.SSLRootCert,
+		ApplicationName:    d.ApplicationName,
+		SearchPath:         d.SearchPath,
+		StatementTimeout:   d.StatementTimeout,
+		TargetSessionAttrs: d.TargetSessionAttrs,
 	}
+}
 
-	swaggerConfig := SwaggerConfig{
-		Enabled:  getEnvAsBool("KOO_SWAGGER_ENABLED", false),
-		Username: os.Getenv("KOO_SWAGGER_USERNAME"),
-		Password: os.Getenv("KOO_SWAGGER_PASSWORD"),
-	}
+// DSN returns the connection URL with every component escaped, so credentials may contain
+// characters such as @, / or %. When URL is set it is used as the base and ConnParams override its query.
+func (d *DatabaseConfig) DSN() string {
+	return d.dsn(true)
+}
 
-	oTelConfig := OTelConfig{
-		Enabled:  os.Getenv("KOO_OTEL_ENABLED") == "true",
-		Exporter: kootel.OTelExporterType(os.Getenv("KOO_OTEL_EXPORTER")),
-	}
+// DSNWithoutDatabase returns the DSN without the database, e.g. to create or drop databases.
+func (d *DatabaseConfig) DSNWithoutDatabase() string {
+	return d.dsn(false)
+}
 
-	var dbPort int
+func (d *DatabaseConfig) dsn(withDatabase bool) string {
+	var u *url.URL
 
-	dbPort, err = strconv.Atoi(os.Getenv("KOO_DB_PORT"))
-	if validate && err != nil {
-		return nil, fmt.Errorf("invalid db port: %w", err)
-	}
+	if d.URL != "" {
+		parsed, err := url.Parse(d.URL.Reveal())
+		if err != nil {
+			// Validate reports invalid URLs, let the driver surface the error otherwise
+			return d.URL.Reveal()
+		}
 
-	// Parse connection pool settings with defaults
-	maxConns := getEnvAsInt("KOO_DB_MAX_CONNS", 25)
-	minConns := getEnvAsInt("KOO_DB_MIN_CONNS", 5)
-	maxConnLifetime := getEnvAsInt("KOO_DB_MAX_CONN_LIFETIME_MINUTES", 60)
-	maxConnIdleTime := getEnvAsInt("KOO_DB_MAX_CONN_IDLE_TIME_MINUTES", 30)
-	connectionTimeout := getEnvAsInt("KOO_DB_CONNECTION_TIMEOUT_SECONDS", 10)
-
-	databaseConfig := DatabaseConfig{
-		Host:              os.Getenv("KOO_DB_HOST"),
-		Port:              dbPort,
-		Username:          os.Getenv("KOO_DB_USERNAME"),
-		Password:          os.Getenv("KOO_DB_PASSWORD"),
-		Database:          os.Getenv("KOO_DB_DATABASE"),
-		MaxConns:          maxConns,
-		MinConns:          minConns,
-		MaxConnLifetime:   maxConnLifetime,
-		MaxConnIdleTime:   maxConnIdleTime,
-		ConnectionTimeout: connectionTimeout,
+		u = parsed
+	} else {
+		u = &url.URL{
+			Scheme: "postgres",
+			User:   url.UserPassword(d.Username, d.Password.Reveal()),
+			Host:   net.JoinHostPort(d.Host, strconv.Itoa(d.Port)),
+			Path:   "/" + d.Database,
+		}
 	}
 
-	config := Config{
-		App:      appConfig,
-		Swagger:  swaggerConfig,
-		OTel:     oTelConfig,
-		Database: databaseConfig,
+	if !withDatabase {
+		u.Path = ""
 	}
 
-	if validate {
-		err = config.Validate()
-		if err != nil {
-			return nil, fmt.Errorf("invalid config: %w", err)
-		}
-	}
+	d.ConnParams().Apply(u)
 
-	return &config, nil
+	return u.String()
 }
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"reflect"
+	"slices"
+)
+
+// RedactedValue replaces secret values in any human readable output.
+const RedactedValue = "******"
+
+// Entry describes the effective value of a single configuration key and where it came from.
+type Entry struct {
+	Key        string `json:"key"`
+	Value      string `json:"value"`
+	IsSet      bool   `json:"isSet"`
+	Source     Source `json:"source,omitempty"`
+	Default    string `json:"default,omitempty"`
+	HasDefault bool   `json:"hasDefault"`
+	Required   bool   `json:"required"`
+	Secret     bool   `json:"secret"`
+}
+
+// Entries resolves every configuration key against the sources described by opts, in declaration order.
+// Values are returned as they were provided, without parsing, so that invalid configurations can still
+// be inspected. Secret values are replaced with RedactedValue.
+func Entries(opts Options) ([]Entry, error) {
+	r, err := newResolver(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	configFields := fields(reflect.TypeFor[Config]())
+	entries := make([]Entry, 0, len(configFields))
+
+	for _, f := range configFields {
+		entry := Entry{
+			Key:        f.key,
+			Default:    f.defaultValue,
+			HasDefault: f.hasDefault,
+			Required:   f.required,
+			Secret:     f.secret,
+		}
+
+		if value, source, ok := r.resolve(f.key); ok {
+			entry.Value, entry.Source, entry.IsSet = value, source, true
+		} else if f.hasDefault {
+			entry.Value, entry.Source, entry.IsSet = f.defaultValue, SourceDefault, true
+		}
+
+		if entry.Secret && entry.Value != "" {
+			entry.Value = RedactedValue
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// LookupEntry returns the entry for key, which is normalized with NormalizeKey.
+func LookupEntry(entries []Entry, key string) (Entry, bool) {
+	key = NormalizeKey(key)
+
+	i := slices.IndexFunc(entries, func(e Entry) bool { return e.Key == key })
+	if i < 0 {
+		return Entry{}, false
+	}
+
+	return entries[i], true
+}
@@ -0,0 +1,84 @@
+package config_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kootic/koogo/internal/config"
+)
+
+func TestEntries(t *testing.T) {
+	t.Parallel()
+
+	passwordFile := writeConfigFile(t, "db-password", "db-s3cr3t\n")
+
+	entries, err := config.Entries(config.Options{
+		ConfigFile: writeConfigFile(t, "koogo.yaml", "db:\n  host: file-host\nswagger:\n  password: swagger-s3cr3t\n"),
+		EnvFile:    writeConfigFile(t, ".env", "KOO_DB_MAX_CONNS=20\n"),
+		Overrides:  []string{"db.password_file=" + passwordFile, "app.admin_password=secret://admin-s3cr3t"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		key        string
+		wantValue  string
+		wantSource config.Source
+		wantSet    bool
+		wantSecret bool
+		wantDef    string
+	}{
+		{key: "db.host", wantValue: "file-host", wantSource: config.SourceFile, wantSet: true},
+		{key: "db.max_conns", wantValue: "20", wantSource: config.SourceEnvFile, wantSet: true, wantDef: "25"},
+		{key: "db.min_conns", wantValue: "5", wantSource: config.SourceDefault, wantSet: true, wantDef: "5"},
+		{
+			key:        "db.password",
+			wantValue:  config.RedactedValue,
+			wantSource: config.SourceFlag,
+			wantSet:    true,
+			wantSecret: true,
+		},
+		{
+			key:        "swagger.password",
+			wantValue:  config.RedactedValue,
+			wantSource: config.SourceFile,
+			wantSet:    true,
+			wantSecret: true,
+		},
+		{
+			key:        "app.admin_password",
+			wantValue:  config.RedactedValue,
+			wantSource: config.SourceFlag,
+			wantSet:    true,
+			wantSecret: true,
+		},
+		{key: "db.url", wantSecret: true},
+	}
+
+	for _, tt := range tests {
+		entry, ok := config.LookupEntry(entries, tt.key)
+		if !ok {
+			t.Fatalf("%s: expected an entry", tt.key)
+		}
+
+		if entry.Value != tt.wantValue || entry.Source != tt.wantSource || entry.IsSet != tt.wantSet {
+			t.Fatalf("%s: expected value %q from %q (set %t), got %q from %q (set %t)",
+				tt.key, tt.wantValue, tt.wantSource, tt.wantSet, entry.Value, entry.Source, entry.IsSet)
+		}
+
+		if entry.Secret != tt.wantSecret || entry.Default != tt.wantDef || entry.HasDefault != (tt.wantDef != "") {
+			t.Fatalf("%s: expected secret %t and default %q, got %+v", tt.key, tt.wantSecret, tt.wantDef, entry)
+		}
+	}
+
+	for _, entry := range entries {
+		if strings.Contains(entry.Value, "s3cr3t") {
+			t.Fatalf("secret leaked in entry %+v", entry)
+		}
+	}
+
+	if _, ok := config.LookupEntry(entries, "db.hots"); ok {
+		t.Fatal("expected no entry for an unknown key")
+	}
+}
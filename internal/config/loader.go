@@ -0,0 +1,245 @@
+package config
+
+import (
+	"encoding"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Struct tags understood by the config loader.
+const (
+	tagEnv      = "env"
+	tagDefault  = "default"
+	tagRequired = "required"
+)
+
+// sliceSeparator separates the elements of slice values, e.g. KOO_FOO=a,b,c.
+const sliceSeparator = ","
+
+var (
+	durationType        = reflect.TypeFor[time.Duration]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+	secretType          = reflect.TypeFor[Secret]()
+)
+
+// LookupFunc returns the raw value for a configuration key and whether it was set.
+type LookupFunc func(key string) (string, bool)
+
+// FieldError describes a problem with a single configuration key.
+type FieldError struct {
+	Key    string // Environment variable name, e.g. KOO_APP_PORT
+	Field  string // Path of the field in Config, e.g. App.Port
+	Reason string
+
+	dependsOn string // Key of another field the check compared against, see validator.compare
+}
+
+func (e FieldError) Error() string {
+	return e.Key + ": " + e.Reason
+}
+
+// FieldErrors collects every problem found in the configuration so that they
+// can be reported at once instead of one per restart.
+type FieldErrors []FieldError
+
+func (e FieldErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, fieldErr := range e {
+		msgs[i] = fieldErr.Error()
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// Load populates dst, which must be a pointer to a struct, from the values returned by lookup.
+// Fields are bound with the `env:"KOO_..."` tag, fall back to the `default:"..."` tag when unset
+// and are reported as missing when tagged `required:"true"` and enforceRequired is set.
+// Nested structs without an env tag are loaded recursively. Every malformed or missing
+// value is collected and returned as FieldErrors.
+func Load(dst any, lookup LookupFunc, enforceRequired bool) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("config destination must be a pointer to a struct, got %T", dst)
+	}
+
+	var errs FieldErrors
+
+	for _, f := range fields(v.Elem().Type()) {
+		raw, ok := lookup(f.key)
+		if !ok {
+			raw, ok = f.defaultValue, f.hasDefault
+		}
+
+		if !ok {
+			if enforceRequired && f.required {
+				errs = append(errs, FieldError{Key: f.key, Field: f.name, Reason: "is required"})
+			}
+
+			continue
+		}
+
+		if err := setValue(v.Elem().FieldByIndex(f.path), raw); err != nil {
+			errs = append(errs, FieldError{Key: f.key, Field: f.name, Reason: err.Error()})
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// LookupEnv is a LookupFunc backed by the process environment. Empty variables are treated as unset.
+func LookupEnv(key string) (string, bool) {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return "", false
+	}
+
+	return value, true
+}
+
+// field describes a configuration value bound through struct tags.
+type field struct {
+	key          string
+	name         string // dotted path of the field, e.g. App.Port
+	defaultValue string
+	hasDefault   bool
+	required     bool
+	secret       bool  // redacted in any human readable output, see Secret
+	path         []int // field index path, see reflect.Value.FieldByIndex
+}
+
+// fields returns every tagged field of the struct type t, including nested structs, in declaration order.
+func fields(t reflect.Type) []field {
+	var result []field
+
+	for i := range t.NumField() {
+		structField := t.Field(i)
+		if !structField.IsExported() {
+			continue
+		}
+
+		key, ok := structField.Tag.Lookup(tagEnv)
+		if !ok {
+			if structField.Type.Kind() == reflect.Struct && !isScalar(structField.Type) {
+				for _, nested := range fields(structField.Type) {
+					nested.path = append([]int{i}, nested.path...)
+					nested.name = structField.Name + "." + nested.name
+					result = append(result, nested)
+				}
+			}
+
+			continue
+		}
+
+		defaultValue, hasDefault := structField.Tag.Lookup(tagDefault)
+
+		result = append(result, field{
+			key:          key,
+			name:         structField.Name,
+			defaultValue: defaultValue,
+			hasDefault:   hasDefault,
+			required:     structField.Tag.Get(tagRequired) == "true",
+			secret:       structField.Type == secretType,
+			path:         []int{i},
+		})
+	}
+
+	return result
+}
+
+// isScalar reports whether values of t are parsed from a single string rather than loaded field by field.
+func isScalar(t reflect.Type) bool {
+	return reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
+// setValue parses raw into v according to v's type.
+func setValue(v reflect.Value, raw string) error {
+	if isScalar(v.Type()) {
+		if err := v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(raw)); err != nil {
+			return fmt.Errorf("invalid value %q: %w", raw, err)
+		}
+
+		return nil
+	}
+
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q", raw)
+		}
+
+		v.SetInt(int64(d))
+
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("invalid bool %q", raw)
+		}
+
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", raw)
+		}
+
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid unsigned integer %q", raw)
+		}
+
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid number %q", raw)
+		}
+
+		v.SetFloat(f)
+	case reflect.Slice:
+		return setSlice(v, raw)
+	default:
+		return fmt.Errorf("unsupported config type %s", v.Type())
+	}
+
+	return nil
+}
+
+// setSlice parses a comma separated list into v. Elements are trimmed and empty elements are skipped.
+func setSlice(v reflect.Value, raw string) error {
+	parts := strings.Split(raw, sliceSeparator)
+	slice := reflect.MakeSlice(v.Type(), 0, len(parts))
+
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		elem := reflect.New(v.Type().Elem()).Elem()
+		if err := setValue(elem, part); err != nil {
+			return err
+		}
+
+		slice = reflect.Append(slice, elem)
+	}
+
+	v.Set(slice)
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package config_test
+
+import (
+	"errors"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kootic/koogo/internal/config"
+)
+
+type ipValue struct {
+	net.IP
+}
+
+func (i *ipValue) UnmarshalText(text []byte) error {
+	return i.IP.UnmarshalText(text)
+}
+
+type loaderTestConfig struct {
+	Name    string        `env:"TEST_NAME"    required:"true"`
+	Port    int           `env:"TEST_PORT"    default:"8080"`
+	Debug   bool          `env:"TEST_DEBUG"`
+	Timeout time.Duration `env:"TEST_TIMEOUT" default:"5s"`
+	Tags    []string      `env:"TEST_TAGS"`
+	Ports   []int         `env:"TEST_PORTS"`
+	IP      ipValue       `env:"TEST_IP"`
+	Nested  struct {
+		Value string `env:"TEST_NESTED_VALUE" default:"nested"`
+	}
+}
+
+func mapLookup(values map[string]string) config.LookupFunc {
+	return func(key string) (string, bool) {
+		value, ok := values[key]
+		return value, ok
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	var cfg loaderTestConfig
+
+	err := config.Load(&cfg, mapLookup(map[string]string{
+		"TEST_NAME":    "koogo",
+		"TEST_DEBUG":   "true",
+		"TEST_TAGS":    "a, b,,c",
+		"TEST_PORTS":   "1,2",
+		"TEST_IP":      "10.0.0.1",
+		"TEST_TIMEOUT": "1m",
+	}), true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.Name != "koogo" || cfg.Port != 8080 || !cfg.Debug || cfg.Timeout != time.Minute {
+		t.Fatalf("unexpected scalar values: %+v", cfg)
+	}
+
+	if !reflect.DeepEqual(cfg.Tags, []string{"a", "b", "c"}) || !reflect.DeepEqual(cfg.Ports, []int{1, 2}) {
+		t.Fatalf("unexpected slice values: %v %v", cfg.Tags, cfg.Ports)
+	}
+
+	if cfg.IP.String() != "10.0.0.1" {
+		t.Fatalf("unexpected text unmarshaler value: %s", cfg.IP)
+	}
+
+	if cfg.Nested.Value != "nested" {
+		t.Fatalf("unexpected nested value: %s", cfg.Nested.Value)
+	}
+}
+
+func TestLoadReportsAllErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		values          map[string]string
+		enforceRequired bool
+		wantKeys        []string
+	}{
+		{
+			name:            "malformed and missing",
+			values:          map[string]string{"TEST_PORT": "abc", "TEST_DEBUG": "maybe", "TEST_PORTS": "1,x"},
+			enforceRequired: true,
+			wantKeys:        []string{"TEST_NAME", "TEST_PORT", "TEST_DEBUG", "TEST_PORTS"},
+		},
+		{
+			name:            "malformed without required enforcement",
+			values:          map[string]string{"TEST_TIMEOUT": "soon", "TEST_IP": "not-an-ip"},
+			enforceRequired: false,
+			wantKeys:        []string{"TEST_TIMEOUT", "TEST_IP"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var cfg loaderTestConfig
+
+			err := config.Load(&cfg, mapLookup(tt.values), tt.enforceRequired)
+
+			var fieldErrs config.FieldErrors
+			if !errors.As(err, &fieldErrs) {
+				t.Fatalf("expected FieldErrors, got %v", err)
+			}
+
+			gotKeys := make([]string, len(fieldErrs))
+			for i, fieldErr := range fieldErrs {
+				gotKeys[i] = fieldErr.Key
+			}
+
+			if !reflect.DeepEqual(gotKeys, tt.wantKeys) {
+				t.Fatalf("expected errors for %v, got %v", tt.wantKeys, err)
+			}
+		})
+	}
+}
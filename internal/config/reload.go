@@ -0,0 +1,74 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// runtimeFieldPrefix is the field path prefix of the reloadable section, see RuntimeConfig.
+const runtimeFieldPrefix = "Runtime."
+
+// Reloader holds the reloadable RuntimeConfig section, swaps it atomically and notifies subscribers.
+// Every other section keeps the values the process was started with.
+type Reloader struct {
+	mu          sync.Mutex
+	config      *Config
+	runtime     atomic.Pointer[RuntimeConfig]
+	subscribers []func(RuntimeConfig)
+}
+
+// NewReloader returns a Reloader serving the runtime section of cfg.
+func NewReloader(cfg *Config) *Reloader {
+	r := &Reloader{config: cfg}
+
+	runtime := cfg.Runtime
+	r.runtime.Store(&runtime)
+
+	return r
+}
+
+// Runtime returns the current runtime settings. It is safe for concurrent use.
+func (r *Reloader) Runtime() RuntimeConfig {
+	return *r.runtime.Load()
+}
+
+// Subscribe registers fn to be called with the new runtime settings after every Apply.
+func (r *Reloader) Subscribe(fn func(RuntimeConfig)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.subscribers = append(r.subscribers, fn)
+}
+
+// Apply swaps in the runtime section of cfg, which must already be validated, and notifies subscribers.
+// It returns the keys of non-reloadable settings that differ from the running config, which only take
+// effect after a restart.
+func (r *Reloader) Apply(cfg *Config) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	runtime := cfg.Runtime
+	r.runtime.Store(&runtime)
+
+	for _, fn := range r.subscribers {
+		fn(runtime)
+	}
+
+	var restartKeys []string
+
+	current, next := reflect.ValueOf(r.config).Elem(), reflect.ValueOf(cfg).Elem()
+
+	for _, f := range fields(current.Type()) {
+		if strings.HasPrefix(f.name, runtimeFieldPrefix) {
+			continue
+		}
+
+		if !reflect.DeepEqual(current.FieldByIndex(f.path).Interface(), next.FieldByIndex(f.path).Interface()) {
+			restartKeys = append(restartKeys, f.key)
+		}
+	}
+
+	return restartKeys
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// secretRefPrefix marks values that must be resolved through a SecretProvider, e.g. secret://db-password.
+const secretRefPrefix = "secret://"
+
+// fileKeySuffix marks keys whose value is read from the file they point at, e.g. KOO_DB_PASSWORD_FILE,
+// which is how Docker and Kubernetes mount secrets.
+const fileKeySuffix = "_FILE"
+
+// Secret is a sensitive string that redacts itself when printed, logged or marshalled to JSON.
+// Use Reveal to access the underlying value.
+type Secret string
+
+// Reveal returns the plaintext value of the secret.
+func (s Secret) Reveal() string {
+	return string(s)
+}
+
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+
+	return RedactedValue
+}
+
+func (s Secret) GoString() string {
+	return fmt.Sprintf("config.Secret(%q)", s.String())
+}
+
+func (s Secret) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// SecretProvider resolves secret references such as secret://db-password while the config is loaded.
+type SecretProvider interface {
+	GetSecret(ctx context.Context, name string) (string, error)
+}
+
+// ErrSecretNotFound is returned by a SecretProvider when the secret does not exist.
+var ErrSecretNotFound = errors.New("secret not found")
+
+type fileSecretProvider struct {
+	dir string
+}
+
+// NewFileSecretProvider returns a SecretProvider that reads each secret from a file named after it in dir.
+// It is intended for local development and tests.
+func NewFileSecretProvider(dir string) SecretProvider {
+	return &fileSecretProvider{dir: dir}
+}
+
+func (p *fileSecretProvider) GetSecret(ctx context.Context, name string) (string, error) {
+	if !filepath.IsLocal(name) {
+		return "", fmt.Errorf("invalid secret name %q", name)
+	}
+
+	value, err := readSecretFile(filepath.Join(p.dir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("%w: %s", ErrSecretNotFound, name)
+	}
+
+	return value, err
+}
+
+// readSecretFile reads a secret from path, trimming the trailing newline most editors and tools add.
+func readSecretFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
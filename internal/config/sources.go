@@ -0,0 +1,483 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/BurntSushi/toml"
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// keyPrefix is prepended to every configuration key to prevent conflicts with other applications.
+const keyPrefix = "KOO_"
+
+// configFileEnvKey points at a config file when the --config flag is not provided.
+const configFileEnvKey = "KOO_CONFIG_FILE"
+
+// Source identifies where a configuration value was read from.
+type Source string
+
+// Sources in increasing order of precedence.
+const (
+	SourceDefault Source = "default"
+	SourceFile    Source = "file"
+	SourceEnvFile Source = "env-file"
+	SourceEnv     Source = "env"
+	SourceFlag    Source = "flag"
+)
+
+// Options controls where LoadConfig reads configuration from. Values are merged with the following
+// precedence, from lowest to highest:
+//
+//  1. `default` struct tags
+//  2. ConfigFile, a YAML (.yaml, .yml) or TOML (.toml) file
+//  3. EnvFile, a dotenv file
+//  4. Environment variables
+//  5. Overrides, usually from --set flags
+//
+// In every source, a KEY_FILE entry is read from the file it points at when KEY is not set in the same source,
+// and values of the form secret://name are resolved through SecretProvider.
+type Options struct {
+	// ConfigFile falls back to the KOO_CONFIG_FILE environment variable when empty.
+	ConfigFile string
+	// EnvFile is always loaded when set. When empty, .env is loaded if it exists and
+	// the app env resolves to "local", since env files are not included in the build.
+	EnvFile string
+	// Overrides are key=value pairs, e.g. db.host=localhost or KOO_DB_HOST=localhost.
+	Overrides []string
+	// SecretProvider resolves secret://name references. Loading fails if a reference is found without one.
+	SecretProvider SecretProvider
+	// Validate enforces required values and runs Config.Validate.
+	Validate bool
+}
+
+// LoadConfig merges all configuration sources described by opts into a Config.
+// Keys in the config file and overrides are normalized with NormalizeKey, nested file
+// sections are joined with underscores, so `db: {host: x}` sets KOO_DB_HOST.
+func LoadConfig(ctx context.Context, opts Options) (*Config, error) {
+	r, err := newResolver(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var config Config
+
+	var errs FieldErrors
+
+	errs = append(errs, r.errs...)
+	errs = append(errs, r.unknownKeys()...)
+	errs = append(errs, r.resolveSecrets(ctx, opts.SecretProvider)...)
+
+	if err := Load(&config, r.lookup, opts.Validate); err != nil {
+		var fieldErrs FieldErrors
+		if !errors.As(err, &fieldErrs) {
+			return nil, err
+		}
+
+		errs = append(errs, fieldErrs...)
+	}
+
+	var validateErr error
+
+	if opts.Validate {
+		var validateErrs FieldErrors
+
+		validateErrs, validateErr = validationErrors(&config, errs)
+		errs = append(errs, validateErrs...)
+	}
+
+	if len(errs) > 0 && validateErr != nil {
+		return nil, fmt.Errorf("invalid config: %w", errors.Join(errs, validateErr))
+	}
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid config: %w", errs)
+	}
+
+	if validateErr != nil {
+		return nil, fmt.Errorf("invalid config: %w", validateErr)
+	}
+
+	return &config, nil
+}
+
+// validationErrors returns the errors from Config.Validate for keys that have not already been reported,
+// leaving out checks that compared against a reported key, and the errors that are not FieldErrors.
+func validationErrors(config *Config, reported FieldErrors) (FieldErrors, error) {
+	validateErrs, others := splitErrors(config.Validate())
+
+	isReported := func(key string) bool {
+		return slices.ContainsFunc(reported, func(e FieldError) bool { return e.Key == key })
+	}
+
+	var errs FieldErrors
+
+	for _, validateErr := range validateErrs {
+		if isReported(validateErr.Key) || (validateErr.dependsOn != "" && isReported(validateErr.dependsOn)) {
+			continue
+		}
+
+		errs = append(errs, validateErr)
+	}
+
+	return errs, errors.Join(others...)
+}
+
+// NormalizeKey converts a user supplied key such as "db.host", "db-host" or "KOO_DB_HOST"
+// into the canonical environment variable form KOO_DB_HOST.
+func NormalizeKey(key string) string {
+	key = strings.ToUpper(strings.TrimSpace(key))
+	key = strings.NewReplacer(".", "_", "-", "_").Replace(key)
+
+	if !strings.HasPrefix(key, keyPrefix) {
+		key = keyPrefix + key
+	}
+
+	return key
+}
+
+// layer holds the values provided by a single source.
+type layer struct {
+	source Source
+	values map[string]string
+}
+
+// resolver looks up keys across layers, the last layer taking precedence.
+type resolver struct {
+	layers []layer
+	keys   []string // config keys in declaration order
+	known  map[string]bool
+	errs   FieldErrors // problems found while reading KEY_FILE entries
+}
+
+func newResolver(opts Options) (*resolver, error) {
+	r := &resolver{known: make(map[string]bool)}
+	for _, f := range fields(reflect.TypeFor[Config]()) {
+		r.keys = append(r.keys, f.key)
+		r.known[f.key] = true
+	}
+
+	configFile := opts.ConfigFile
+	if configFile == "" {
+		configFile, _ = LookupEnv(configFileEnvKey)
+	}
+
+	if configFile != "" {
+		values, err := readConfigFile(configFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config file %s: %w", configFile, err)
+		}
+
+		r.layers = append(r.layers, layer{source: SourceFile, values: values})
+	}
+
+	envValues := environ()
+
+	overrides, err := parseOverrides(opts.Overrides)
+	if err != nil {
+		return nil, err
+	}
+
+	envFileValues, err := readEnvFile(opts.EnvFile, func() AppEnv {
+		appEnv := AppEnvLocal
+
+		for _, values := range []map[string]string{r.values(SourceFile), envValues, overrides} {
+			if value, ok := values["KOO_APP_ENV"]; ok {
+				appEnv = AppEnv(value)
+			}
+		}
+
+		return appEnv
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	exportEnv(envFileValues)
+
+	r.layers = append(r.layers,
+		layer{source: SourceEnvFile, values: envFileValues},
+		layer{source: SourceEnv, values: envValues},
+		layer{source: SourceFlag, values: overrides},
+	)
+
+	for _, l := range r.layers {
+		r.expandFileKeys(l)
+	}
+
+	return r, nil
+}
+
+// expandFileKeys sets KEY from the contents of the file named by KEY_FILE when KEY is not set in the same layer.
+func (r *resolver) expandFileKeys(l layer) {
+	for _, fileKey := range slices.Sorted(maps.Keys(l.values)) {
+		key, ok := strings.CutSuffix(fileKey, fileKeySuffix)
+		if !ok || !r.known[key] {
+			continue
+		}
+
+		if _, ok := l.values[key]; ok {
+			continue
+		}
+
+		value, err := readSecretFile(l.values[fileKey])
+		if err != nil {
+			r.errs = append(r.errs, FieldError{Key: fileKey, Reason: err.Error()})
+			continue
+		}
+
+		l.values[key] = value
+	}
+}
+
+// resolveSecrets replaces effective values of the form secret://name with the value returned by provider.
+func (r *resolver) resolveSecrets(ctx context.Context, provider SecretProvider) FieldErrors {
+	var errs FieldErrors
+
+	for _, key := range r.keys {
+		l, ok := r.layerFor(key)
+		if !ok {
+			continue
+		}
+
+		name, ok := strings.CutPrefix(l.values[key], secretRefPrefix)
+		if !ok {
+			continue
+		}
+
+		if provider == nil {
+			errs = append(errs, FieldError{Key: key, Reason: "references a secret but no secret provider is configured"})
+			continue
+		}
+
+		value, err := provider.GetSecret(ctx, name)
+		if err != nil {
+			errs = append(errs, FieldError{Key: key, Reason: fmt.Sprintf("failed to resolve secret: %v", err)})
+			continue
+		}
+
+		l.values[key] = value
+	}
+
+	return errs
+}
+
+func (r *resolver) lookup(key string) (string, bool) {
+	value, _, ok := r.resolve(key)
+	return value, ok
+}
+
+// resolve returns the value for key from the highest precedence layer that sets it.
+func (r *resolver) resolve(key string) (string, Source, bool) {
+	l, ok := r.layerFor(key)
+	if !ok {
+		return "", "", false
+	}
+
+	return l.values[key], l.source, true
+}
+
+// layerFor returns the highest precedence layer that sets key.
+func (r *resolver) layerFor(key string) (layer, bool) {
+	for i := len(r.layers) - 1; i >= 0; i-- {
+		if _, ok := r.layers[i].values[key]; ok {
+			return r.layers[i], true
+		}
+	}
+
+	return layer{}, false
+}
+
+func (r *resolver) values(source Source) map[string]string {
+	for _, l := range r.layers {
+		if l.source == source {
+			return l.values
+		}
+	}
+
+	return nil
+}
+
+// unknownKeys reports keys from the config file and overrides that do not map to any config field,
+// which usually means a typo. Environment variables are not checked as they are shared with other programs.
+func (r *resolver) unknownKeys() FieldErrors {
+	var errs FieldErrors
+
+	for _, source := range []Source{SourceFile, SourceFlag} {
+		for _, key := range slices.Sorted(maps.Keys(r.values(source))) {
+			if !r.known[key] && !r.known[strings.TrimSuffix(key, fileKeySuffix)] {
+				errs = append(errs, FieldError{Key: key, Reason: fmt.Sprintf("unknown key from %s", source)})
+			}
+		}
+	}
+
+	return errs
+}
+
+// exportedEnv records the environment variables set by exportEnv and their values, so they are not
+// mistaken for the environment layer when the config is loaded again, e.g. on reload.
+var exportedEnv = struct {
+	sync.Mutex
+	values map[string]string
+}{values: make(map[string]string)}
+
+// environ returns the non-empty environment variables, leaving out those set from an env file by exportEnv.
+func environ() map[string]string {
+	exportedEnv.Lock()
+	defer exportedEnv.Unlock()
+
+	values := make(map[string]string)
+
+	for _, kv := range os.Environ() {
+		key, value, _ := strings.Cut(kv, "=")
+		if exported, ok := exportedEnv.values[key]; ok && exported == value {
+			continue
+		}
+
+		if value != "" {
+			values[key] = value
+		}
+	}
+
+	return values
+}
+
+// exportEnv sets values that are not already present in the environment, matching godotenv.Load,
+// so that libraries configured through their own environment variables (e.g. OTEL_*) see them too.
+// Variables exported by an earlier load are updated, or unset when the env file no longer sets them.
+func exportEnv(values map[string]string) {
+	exportedEnv.Lock()
+	defer exportedEnv.Unlock()
+
+	owned := make(map[string]bool)
+
+	for key, exported := range exportedEnv.values {
+		delete(exportedEnv.values, key)
+
+		// The variable was changed by someone else since it was exported
+		if value, ok := os.LookupEnv(key); !ok || value != exported {
+			continue
+		}
+
+		if _, ok := values[key]; ok {
+			owned[key] = true
+		} else {
+			_ = os.Unsetenv(key)
+		}
+	}
+
+	for key, value := range values {
+		if _, ok := os.LookupEnv(key); ok && !owned[key] {
+			continue
+		}
+
+		_ = os.Setenv(key, value)
+		exportedEnv.values[key] = value
+	}
+}
+
+// readEnvFile reads the dotenv file at path. When path is empty, the default .env file is read only
+// when appEnv resolves to local and a missing file is not an error.
+func readEnvFile(path string, appEnv func() AppEnv) (map[string]string, error) {
+	if path != "" {
+		values, err := godotenv.Read(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read env file %s: %w", path, err)
+		}
+
+		return values, nil
+	}
+
+	// This is only for local development, env files will not be included in the build and we rely on environment variables
+	if appEnv() != AppEnvLocal {
+		return nil, nil
+	}
+
+	values, err := godotenv.Read(".env")
+	if err != nil {
+		log.Println("Unable to load .env file, using environment variables only; error:", err)
+		return nil, nil
+	}
+
+	return values, nil
+}
+
+// readConfigFile decodes a YAML or TOML file into flattened, normalized keys.
+func readConfigFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := make(map[string]any)
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &raw)
+	case ".toml":
+		err = toml.Unmarshal(data, &raw)
+	default:
+		return nil, fmt.Errorf("unsupported config file extension %q, expected .yaml, .yml or .toml", ext)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string)
+	flatten(values, "", raw)
+
+	return values, nil
+}
+
+// flatten joins nested keys with underscores and converts values to their string form.
+// Lists are joined with commas to match how slices are parsed from environment variables.
+func flatten(dst map[string]string, prefix string, src map[string]any) {
+	for key, value := range src {
+		if prefix != "" {
+			key = prefix + "_" + key
+		}
+
+		switch v := value.(type) {
+		case map[string]any:
+			flatten(dst, key, v)
+		case []any:
+			items := make([]string, len(v))
+			for i, item := range v {
+				items[i] = fmt.Sprint(item)
+			}
+
+			dst[NormalizeKey(key)] = strings.Join(items, sliceSeparator)
+		case nil:
+			continue
+		default:
+			dst[NormalizeKey(key)] = fmt.Sprint(v)
+		}
+	}
+}
+
+// parseOverrides parses key=value pairs into normalized keys.
+func parseOverrides(overrides []string) (map[string]string, error) {
+	values := make(map[string]string, len(overrides))
+
+	for _, override := range overrides {
+		key, value, ok := strings.Cut(override, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("invalid override %q, expected key=value", override)
+		}
+
+		values[NormalizeKey(key)] = value
+	}
+
+	return values, nil
+}
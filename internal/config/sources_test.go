@@ -0,0 +1,217 @@
+package config_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kootic/koogo/internal/config"
+)
+
+func writeConfigFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	return path
+}
+
+func TestLoadConfigPrecedence(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		file     string
+		content  string
+		override string
+	}{
+		{
+			name:     "yaml",
+			file:     "koogo.yaml",
+			content:  "app:\n  name: koogo\n  port: 8080\ndb:\n  host: file-host\n  max_conns: 10\n",
+			override: "db.host=flag-host",
+		},
+		{
+			name:     "toml",
+			file:     "koogo.toml",
+			content:  "[app]\nname = \"koogo\"\nport = 8080\n\n[db]\nhost = \"file-host\"\nmax_conns = 10\n",
+			override: "KOO_DB_HOST=flag-host",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, err := config.LoadConfig(t.Context(), config.Options{
+				ConfigFile: writeConfigFile(t, tt.file, tt.content),
+				EnvFile:    writeConfigFile(t, ".env", "KOO_DB_MAX_CONNS=20\n"),
+				Overrides:  []string{tt.override},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if cfg.App.Name != "koogo" || cfg.App.Port != 8080 {
+				t.Fatalf("expected values from config file, got %+v", cfg.App)
+			}
+
+			if cfg.Database.MaxConns != 20 {
+				t.Fatalf("expected env file to override config file, got %d", cfg.Database.MaxConns)
+			}
+
+			if cfg.Database.Host != "flag-host" {
+				t.Fatalf("expected override to take precedence, got %s", cfg.Database.Host)
+			}
+
+			if cfg.Database.MinConns != 5 {
+				t.Fatalf("expected default value, got %d", cfg.Database.MinConns)
+			}
+		})
+	}
+}
+
+func TestLoadConfigUnknownKey(t *testing.T) {
+	t.Parallel()
+
+	_, err := config.LoadConfig(t.Context(), config.Options{
+		ConfigFile: writeConfigFile(t, "koogo.yaml", "db:\n  hots: localhost\n"),
+		EnvFile:    writeConfigFile(t, ".env", ""),
+	})
+
+	var fieldErrs config.FieldErrors
+	if !errors.As(err, &fieldErrs) || len(fieldErrs) != 1 || fieldErrs[0].Key != "KOO_DB_HOTS" {
+		t.Fatalf("expected unknown key error for KOO_DB_HOTS, got %v", err)
+	}
+}
+
+func TestLoadConfigSecrets(t *testing.T) {
+	t.Parallel()
+
+	secretsDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(secretsDir, "swagger-password"), []byte("swagger-secret\n"), 0o600); err != nil {
+		t.Fatalf("failed to write secret: %v", err)
+	}
+
+	passwordFile := writeConfigFile(t, "db-password", "db-secret\n")
+
+	cfg, err := config.LoadConfig(t.Context(), config.Options{
+		ConfigFile:     writeConfigFile(t, "koogo.yaml", "swagger:\n  password: secret://swagger-password\n"),
+		EnvFile:        writeConfigFile(t, ".env", ""),
+		Overrides:      []string{"db.password_file=" + passwordFile},
+		SecretProvider: config.NewFileSecretProvider(secretsDir),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.Database.Password.Reveal() != "db-secret" {
+		t.Fatalf("expected password from file, got %q", cfg.Database.Password.Reveal())
+	}
+
+	if cfg.Swagger.Password.Reveal() != "swagger-secret" {
+		t.Fatalf("expected password from secret provider, got %q", cfg.Swagger.Password.Reveal())
+	}
+
+	data, err := json.Marshal(cfg.Database)
+	if err != nil {
+		t.Fatalf("failed to marshal config: %v", err)
+	}
+
+	for _, output := range []string{string(data), fmt.Sprintf("%v %+v %#v", cfg.Database, cfg.Database, cfg.Database)} {
+		if strings.Contains(output, "db-secret") {
+			t.Fatalf("secret leaked in output: %s", output)
+		}
+	}
+}
+
+func TestLoadConfigMissingSecretProvider(t *testing.T) {
+	t.Parallel()
+
+	_, err := config.LoadConfig(t.Context(), config.Options{
+		EnvFile:   writeConfigFile(t, ".env", ""),
+		Overrides: []string{"db.password=secret://db-password"},
+	})
+
+	var fieldErrs config.FieldErrors
+	if !errors.As(err, &fieldErrs) || len(fieldErrs) != 1 || fieldErrs[0].Key != "KOO_DB_PASSWORD" {
+		t.Fatalf("expected secret provider error for KOO_DB_PASSWORD, got %v", err)
+	}
+}
+
+// TestLoadConfigReloadsEnvFile is not parallel as loading exports the env file to the process environment.
+func TestLoadConfigReloadsEnvFile(t *testing.T) {
+	if _, ok := os.LookupEnv("KOO_APP_LOG_LEVEL"); ok {
+		t.Skip("KOO_APP_LOG_LEVEL is set in the environment")
+	}
+
+	t.Cleanup(func() { _ = os.Unsetenv("KOO_APP_LOG_LEVEL") })
+
+	envFile := writeConfigFile(t, ".env", "KOO_APP_LOG_LEVEL=info\n")
+
+	tests := []struct {
+		name    string
+		content string
+		want    config.AppLogLevel
+	}{
+		{name: "first load", content: "KOO_APP_LOG_LEVEL=info\n", want: config.AppLogLevelInfo},
+		{name: "edited", content: "KOO_APP_LOG_LEVEL=debug\n", want: config.AppLogLevelDebug},
+		{name: "removed", content: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		if err := os.WriteFile(envFile, []byte(tt.content), 0o600); err != nil {
+			t.Fatalf("failed to write env file: %v", err)
+		}
+
+		cfg, err := config.LoadConfig(t.Context(), config.Options{EnvFile: envFile})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+
+		if cfg.Runtime.LogLevel != tt.want {
+			t.Fatalf("%s: expected log level %q, got %q", tt.name, tt.want, cfg.Runtime.LogLevel)
+		}
+
+		if value := os.Getenv("KOO_APP_LOG_LEVEL"); value != string(tt.want) {
+			t.Fatalf("%s: expected exported log level %q, got %q", tt.name, tt.want, value)
+		}
+	}
+}
+
+func TestLoadConfigSkipsChecksAgainstMalformedValues(t *testing.T) {
+	t.Parallel()
+
+	_, err := config.LoadConfig(t.Context(), config.Options{
+		EnvFile:   writeConfigFile(t, ".env", ""),
+		Overrides: []string{"db.max_conns=abc", "db.min_conns=10"},
+		Validate:  true,
+	})
+
+	var fieldErrs config.FieldErrors
+	if !errors.As(err, &fieldErrs) {
+		t.Fatalf("expected FieldErrors, got %v", err)
+	}
+
+	var reportedMaxConns bool
+
+	for _, fieldErr := range fieldErrs {
+		switch fieldErr.Key {
+		case "KOO_DB_MAX_CONNS":
+			reportedMaxConns = true
+		case "KOO_DB_MIN_CONNS":
+			t.Fatalf("expected no error comparing against the malformed KOO_DB_MAX_CONNS, got %v", fieldErr)
+		}
+	}
+
+	if !reportedMaxConns {
+		t.Fatalf("expected an error for KOO_DB_MAX_CONNS, got %v", err)
+	}
+}
@@ -0,0 +1,122 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+const (
+	minPort = 1
+	maxPort = 65535
+)
+
+// validator accumulates FieldErrors for a config section, resolving field names to their env keys.
+type validator struct {
+	section string
+	t       reflect.Type
+	errs    FieldErrors
+	others  []error // Errors of nested sections that are not FieldErrors
+}
+
+// newValidator returns a validator for section, a pointer to a config struct such as *AppConfig.
+// name is the section's field name in Config and is used to build field paths, e.g. "App.Port".
+func newValidator(name string, section any) *validator {
+	return &validator{
+		section: name,
+		t:       reflect.TypeOf(section).Elem(),
+	}
+}
+
+// check records reason against field when ok is false.
+func (v *validator) check(ok bool, field string, reason string) {
+	if !ok {
+		v.record(field, reason, "")
+	}
+}
+
+// compare records reason against field when ok is false. The check compares field to other, a field of
+// the same section, and is not reported by LoadConfig when the value of other failed to parse.
+func (v *validator) compare(ok bool, field, other string, reason string) {
+	if !ok {
+		v.record(field, reason, v.key(other))
+	}
+}
+
+// add records the errors of a nested section, e.g. the result of its Validate method.
+func (v *validator) add(err error) {
+	fieldErrs, others := splitErrors(err)
+	v.errs = append(v.errs, fieldErrs...)
+	v.others = append(v.others, others...)
+}
+
+func (v *validator) record(field, reason, dependsOn string) {
+	v.errs = append(v.errs, FieldError{
+		Key:       v.key(field),
+		Field:     v.section + "." + field,
+		Reason:    reason,
+		dependsOn: dependsOn,
+	})
+}
+
+// key returns the env key of field.
+func (v *validator) key(field string) string {
+	structField, found := v.t.FieldByName(field)
+	if !found {
+		panic(fmt.Sprintf("config: %s has no field %s", v.t, field))
+	}
+
+	return structField.Tag.Get(tagEnv)
+}
+
+// required records an error when value is the zero value.
+func (v *validator) required(value any, field string) {
+	v.check(!reflect.ValueOf(value).IsZero(), field, "is required")
+}
+
+// port records an error when value is not a valid TCP port.
+func (v *validator) port(value int, field string) {
+	v.check(value >= minPort && value <= maxPort, field, fmt.Sprintf("must be %d-%d", minPort, maxPort))
+}
+
+// positive records an error when value is not greater than zero.
+func (v *validator) positive(value int, field string) {
+	v.check(value > 0, field, "must be greater than 0")
+}
+
+// err returns the accumulated errors, or nil when the section is valid. Errors that are not FieldErrors
+// are joined with them.
+func (v *validator) err() error {
+	var errs []error
+	if len(v.errs) > 0 {
+		errs = append(errs, v.errs)
+	}
+
+	return errors.Join(append(errs, v.others...)...)
+}
+
+// splitErrors separates the FieldErrors in err, which may be joined with other errors, from the rest.
+func splitErrors(err error) (FieldErrors, []error) {
+	var fieldErrs FieldErrors
+
+	switch e := err.(type) {
+	case nil:
+		return nil, nil
+	case interface{ Unwrap() []error }:
+		var others []error
+
+		for _, joined := range e.Unwrap() {
+			errs, rest := splitErrors(joined)
+			fieldErrs = append(fieldErrs, errs...)
+			others = append(others, rest...)
+		}
+
+		return fieldErrs, others
+	}
+
+	if errors.As(err, &fieldErrs) {
+		return fieldErrs, nil
+	}
+
+	return nil, []error{err}
+}
@@ -5,23 +5,13 @@ package dto
 // See docs/BOOTSTRAPPING.md for details.
 
 import (
-	"errors"
-
 	"github.com/google/uuid"
 
 	"github.com/kootic/koogo/internal/domain"
 )
 
 type KooCreateUserRequest struct {
-	FirstName string `json:"firstName"`
-}
-
-func (r *KooCreateUserRequest) Validate() error {
-	if r.FirstName == "" {
-		return errors.New("firstName is required")
-	}
-
-	return nil
+	FirstName string `json:"firstName" validate:"required,max=100"`
 }
 
 func (r *KooCreateUserRequest) ToModel() *domain.KooUser {
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"github.com/kootic/koogo/internal/service"
+	"github.com/kootic/koogo/pkg/koohealth"
 )
 
 type Handler struct {
@@ -9,8 +10,8 @@ type Handler struct {
 	KooUserHandler KooUserHandler
 }
 
-func NewHandler(services *service.Services) *Handler {
-	healthHandler := NewHealthHandler(services.HealthService)
+func NewHandler(services *service.Services, probes *koohealth.Probes) *Handler {
+	healthHandler := NewHealthHandler(services.HealthService, probes)
 	userHandler := NewKooUserHandler(services.KooUserService)
 
 	return &Handler{
@@ -1,43 +1,88 @@
 package handler
 
 import (
+	"net/http"
+
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
 
 	"github.com/kootic/koogo/internal/service"
 	"github.com/kootic/koogo/pkg/kooctx"
+	"github.com/kootic/koogo/pkg/koohealth"
 	"github.com/kootic/koogo/pkg/koohttp"
 )
 
 type HealthHandler interface {
 	HealthCheck(c *fiber.Ctx) error
+	Livez(c *fiber.Ctx) error
+	Readyz(c *fiber.Ctx) error
+	Startupz(c *fiber.Ctx) error
 }
 
 type healthHandler struct {
 	healthService service.HealthService
+	probes        *koohealth.Probes
 }
 
-func NewHealthHandler(healthService service.HealthService) HealthHandler {
-	return &healthHandler{healthService: healthService}
+func NewHealthHandler(healthService service.HealthService, probes *koohealth.Probes) HealthHandler {
+	return &healthHandler{healthService: healthService, probes: probes}
 }
 
 // HealthCheck godoc
 //
 //	@tags			Health
 //	@Summary		Health check endpoint
-//	@Description	Returns the health status of the application
+//	@Description	Returns the health status of the application. Failing critical checks return 503,
+//	@Description	failing non-critical checks only degrade the status.
 //	@Accept			json
 //	@Produce		json
-//	@Success		200
-//	@Failure		503
+//	@Param			verbose	query		bool	false	"Include the status, latency and last error of every check"
+//	@Success		200		{object}	koohealth.Report
+//	@Failure		503		{object}	koohealth.Report
 //	@Router			/v1/health [get]
 func (h *healthHandler) HealthCheck(c *fiber.Ctx) error {
 	ctx := c.UserContext()
 	logger := kooctx.GetContextLogger(ctx)
 
-	// Check database connection
-	if err := h.healthService.HealthCheck(ctx); err != nil {
-		logger.Error("Health check failed", zap.Error(err))
+	report := h.healthService.HealthCheck(ctx)
+
+	status := http.StatusOK
+	if report.Status == koohealth.StatusDown {
+		logger.Error("Health check failed", zap.Any("report", report))
+
+		status = http.StatusServiceUnavailable
+	}
+
+	if c.QueryBool("verbose") {
+		return c.Status(status).JSON(report)
+	}
+
+	if status != http.StatusOK {
+		return koohttp.ServiceUnavailable(c)
+	}
+
+	return koohttp.Success(c, nil)
+}
+
+// Livez reports that the process is running. It has no dependencies, so orchestrators only restart
+// the process when it stops responding altogether.
+func (h *healthHandler) Livez(c *fiber.Ctx) error {
+	return koohttp.Success(c, nil)
+}
+
+// Readyz reports whether the process should receive traffic: startup has completed, shutdown has not
+// begun and no critical health check fails.
+func (h *healthHandler) Readyz(c *fiber.Ctx) error {
+	if !h.probes.Started() || h.probes.Draining() {
+		return koohttp.ServiceUnavailable(c)
+	}
+
+	return h.HealthCheck(c)
+}
+
+// Startupz reports whether startup has completed, until then orchestrators hold off the other probes.
+func (h *healthHandler) Startupz(c *fiber.Ctx) error {
+	if !h.probes.Started() {
 		return koohttp.ServiceUnavailable(c)
 	}
 
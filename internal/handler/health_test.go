@@ -0,0 +1,87 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/kootic/koogo/internal/handler"
+	"github.com/kootic/koogo/internal/service"
+	"github.com/kootic/koogo/pkg/koohealth"
+)
+
+func TestProbes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		started  bool
+		draining bool
+		want     map[string]int
+	}{
+		{
+			name: "starting",
+			want: map[string]int{
+				"/livez":    http.StatusOK,
+				"/readyz":   http.StatusServiceUnavailable,
+				"/startupz": http.StatusServiceUnavailable,
+			},
+		},
+		{
+			name:    "started",
+			started: true,
+			want: map[string]int{
+				"/livez":    http.StatusOK,
+				"/readyz":   http.StatusOK,
+				"/startupz": http.StatusOK,
+			},
+		},
+		{
+			name:     "draining",
+			started:  true,
+			draining: true,
+			want: map[string]int{
+				"/livez":    http.StatusOK,
+				"/readyz":   http.StatusServiceUnavailable,
+				"/startupz": http.StatusOK,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			probes := koohealth.NewProbes()
+			if tt.started {
+				probes.MarkStarted()
+			}
+
+			if tt.draining {
+				probes.MarkDraining()
+			}
+
+			h := handler.NewHealthHandler(service.NewHealthService(koohealth.NewRegistry(0)), probes)
+
+			app := fiber.New()
+			app.Get("/livez", h.Livez)
+			app.Get("/readyz", h.Readyz)
+			app.Get("/startupz", h.Startupz)
+
+			for path, want := range tt.want {
+				resp, err := app.Test(httptest.NewRequest(http.MethodGet, path, nil))
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				_ = resp.Body.Close()
+
+				if resp.StatusCode != want {
+					t.Fatalf("expected %s to return %d, got %d", path, want, resp.StatusCode)
+				}
+			}
+		})
+	}
+}
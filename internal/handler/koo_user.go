@@ -40,6 +40,7 @@ func NewKooUserHandler(userService service.KooUserService) KooUserHandler {
 //	@Param			kooCreateUserRequest	body		dto.KooCreateUserRequest	true	"Create user request"
 //	@Success		200						{object}	dto.KooUserResponse
 //	@Failure		400						{object}	koohttp.APIResponseError
+//	@Failure		422						{object}	koohttp.APIResponseError
 //	@Failure		500						{object}	koohttp.APIResponseError
 //	@Router			/v1/koo/users [post]
 func (h *kooUserHandler) CreateUser(c *fiber.Ctx) error {
@@ -48,7 +49,7 @@ func (h *kooUserHandler) CreateUser(c *fiber.Ctx) error {
 		return err
 	}
 
-	user, err := h.userService.KooCreateUser(c.Context(), req)
+	user, err := h.userService.KooCreateUser(c.UserContext(), req)
 	if err != nil {
 		return err
 	}
@@ -75,7 +76,7 @@ func (h *kooUserHandler) GetUserByID(c *fiber.Ctx) error {
 		return err
 	}
 
-	user, err := h.userService.KooGetUserByID(c.Context(), userID)
+	user, err := h.userService.KooGetUserByID(c.UserContext(), userID)
 	if err != nil {
 		return err
 	}
@@ -103,7 +104,7 @@ func (h *kooUserHandler) GetUserPet(c *fiber.Ctx) error {
 		return err
 	}
 
-	userPet, err := h.userService.KooGetPetByOwnerID(c.Context(), userID)
+	userPet, err := h.userService.KooGetPetByOwnerID(c.UserContext(), userID)
 	if err != nil {
 		return err
 	}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 
+	"go.uber.org/zap"
+
 	"github.com/kootic/koogo/internal/config"
 	"github.com/kootic/koogo/pkg/kooctx"
 	"github.com/kootic/koogo/pkg/koolog"
@@ -23,7 +25,7 @@ var JobsRegistry = map[string]Job{
 }
 
 func RunJob(ctx context.Context, cfg *config.Config, jobID string, flags map[string]string) error {
-	logger, err := koolog.NewLogger(cfg.App.IsProd(), cfg.App.ZapLogLevel())
+	logger, err := koolog.NewLogger(cfg.App.IsProd(), zap.NewAtomicLevelAt(cfg.Runtime.ZapLogLevel()))
 	if err != nil {
 		return fmt.Errorf("failed to create logger: %w", err)
 	}
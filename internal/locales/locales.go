@@ -0,0 +1,28 @@
+// Package locales embeds the translations of the messages sent to clients, see kooi18n.
+package locales
+
+import (
+	"embed"
+	"io/fs"
+
+	"golang.org/x/text/language"
+
+	"github.com/kootic/koogo/pkg/kooi18n"
+)
+
+// Default is the locale of the messages built into the code, e.g. of the error catalog. It is used for
+// clients that accept none of the translated locales and for messages missing from a translation.
+var Default = language.English
+
+//go:embed translations
+var translations embed.FS
+
+// NewBundle loads the embedded translations, one file per locale, e.g. translations/de.json.
+func NewBundle() (*kooi18n.Bundle, error) {
+	files, err := fs.Sub(translations, "translations")
+	if err != nil {
+		return nil, err
+	}
+
+	return kooi18n.NewBundle(files, Default)
+}
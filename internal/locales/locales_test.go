@@ -0,0 +1,58 @@
+package locales_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/kootic/koogo/internal/locales"
+	// Defines the errors of every package in the error catalog
+	_ "github.com/kootic/koogo/internal/server"
+	"github.com/kootic/koogo/pkg/koohttp"
+)
+
+func TestTranslationsCoverEveryMessage(t *testing.T) {
+	t.Parallel()
+
+	bundle, err := locales.NewBundle()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	keys := make(map[string]bool)
+	for _, def := range koohttp.ErrorCatalog() {
+		keys["errors."+def.Code] = true
+	}
+
+	for key := range koohttp.FieldMessages() {
+		keys[key] = true
+	}
+
+	for _, locale := range bundle.Locales() {
+		if locale == locales.Default {
+			continue
+		}
+
+		messages := bundle.Messages(locale)
+
+		var missing, unknown []string
+
+		for key := range keys {
+			if _, ok := messages[key]; !ok {
+				missing = append(missing, key)
+			}
+		}
+
+		for key := range messages {
+			if !keys[key] {
+				unknown = append(unknown, key)
+			}
+		}
+
+		slices.Sort(missing)
+		slices.Sort(unknown)
+
+		if len(missing) > 0 || len(unknown) > 0 {
+			t.Errorf("translations of %s: missing %v, unknown %v", locale, missing, unknown)
+		}
+	}
+}
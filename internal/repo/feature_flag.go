@@ -0,0 +1,8 @@
+package repo
+
+import "github.com/kootic/koogo/pkg/kooflag"
+
+// FeatureFlagRepository serves feature flag definitions stored in the database.
+type FeatureFlagRepository interface {
+	kooflag.Store
+}
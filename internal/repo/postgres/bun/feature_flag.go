@@ -0,0 +1,26 @@
+package bun
+
+import (
+	"github.com/uptrace/bun"
+
+	"github.com/kootic/koogo/pkg/kooflag"
+)
+
+type FeatureFlag struct {
+	bun.BaseModel `bun:"table:feature_flags,alias:ff"`
+
+	Key        string         `bun:"key,pk,type:varchar"`
+	Enabled    bool           `bun:"enabled,notnull,default:false"`
+	Percentage *int           `bun:"percentage,type:integer"`
+	Rules      []kooflag.Rule `bun:"rules,notnull,type:jsonb,default:'[]'"`
+}
+
+// ToFlag converts the database model to a feature flag definition.
+func (f *FeatureFlag) ToFlag() kooflag.Flag {
+	return kooflag.Flag{
+		Key:        f.Key,
+		Enabled:    f.Enabled,
+		Rules:      f.Rules,
+		Percentage: f.Percentage,
+	}
+}
@@ -12,10 +12,30 @@ import (
 )
 
 var (
-	ErrNotFound                = koohttp.NewAPIError(http.StatusNotFound, "database_record_not_found")
-	ErrConstraintViolation     = koohttp.NewAPIError(http.StatusConflict, "database_constraint_violation")
-	ErrTimeout                 = koohttp.NewAPIError(http.StatusRequestTimeout, "database_timeout")
-	ErrInvalidTransactionState = koohttp.NewAPIError(http.StatusInternalServerError, "database_invalid_transaction_state")
+	ErrNotFound = koohttp.DefineError(koohttp.ErrorDefinition{
+		Code:        "database_record_not_found",
+		Status:      http.StatusNotFound,
+		Description: "A record the request refers to does not exist.",
+		Message:     "The record was not found",
+	})
+	ErrConstraintViolation = koohttp.DefineError(koohttp.ErrorDefinition{
+		Code:        "database_constraint_violation",
+		Status:      http.StatusConflict,
+		Description: "The request conflicts with existing records, e.g. a unique or foreign key constraint.",
+		Message:     "The request conflicts with existing records",
+	})
+	ErrTimeout = koohttp.DefineError(koohttp.ErrorDefinition{
+		Code:        "database_timeout",
+		Status:      http.StatusRequestTimeout,
+		Description: "A database statement exceeded the statement timeout.",
+		Message:     "The request timed out",
+	})
+	ErrInvalidTransactionState = koohttp.DefineError(koohttp.ErrorDefinition{
+		Code:        "database_invalid_transaction_state",
+		Status:      http.StatusInternalServerError,
+		Description: "A statement ran in an aborted or finished transaction.",
+		Message:     "An unexpected error occurred",
+	})
 )
 
 func handleError(err error) error {
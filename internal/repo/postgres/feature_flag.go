@@ -0,0 +1,42 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/uptrace/bun"
+
+	"github.com/kootic/koogo/internal/repo"
+	bun1 "github.com/kootic/koogo/internal/repo/postgres/bun"
+	"github.com/kootic/koogo/pkg/kooflag"
+)
+
+type featureFlagRepository struct {
+	db *bun.DB
+}
+
+var _ repo.FeatureFlagRepository = (*featureFlagRepository)(nil)
+
+func NewFeatureFlagRepository(db *bun.DB) repo.FeatureFlagRepository {
+	return &featureFlagRepository{db: db}
+}
+
+func (r *featureFlagRepository) Flag(ctx context.Context, key string) (kooflag.Flag, bool, error) {
+	var pgFlag bun1.FeatureFlag
+
+	err := r.db.
+		NewSelect().
+		Model(&pgFlag).
+		Where("key = ?", key).
+		Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return kooflag.Flag{}, false, nil
+	}
+
+	if err != nil {
+		return kooflag.Flag{}, false, handleError(err)
+	}
+
+	return pgFlag.ToFlag(), true, nil
+}
@@ -0,0 +1,8 @@
+-- Create "feature_flags" table
+CREATE TABLE "public"."feature_flags" (
+  "key" character varying NOT NULL,
+  "enabled" boolean NOT NULL DEFAULT false,
+  "percentage" integer NULL,
+  "rules" jsonb NOT NULL DEFAULT '[]',
+  PRIMARY KEY ("key")
+);
@@ -1,22 +1,38 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
+	"time"
 
 	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/dialect/pgdialect"
 
 	"github.com/kootic/koogo/internal/repo"
+	"github.com/kootic/koogo/pkg/kooctx"
 )
 
 // NewRepositories creates all PostgreSQL repository implementations.
 func NewRepositories(sqlDB *sql.DB) (*repo.Repositories, error) {
 	db := bun.NewDB(sqlDB, pgdialect.New(), bun.WithDiscardUnknownColumns())
+	db.AddQueryHook(queryEventHook{})
 
 	return &repo.Repositories{
-		DB:     db,
-		User:   NewKooUserRepository(db),
-		Pet:    NewKooPetRepository(db),
-		Health: NewHealthRepository(db),
+		DB:          db,
+		User:        NewKooUserRepository(db),
+		Pet:         NewKooPetRepository(db),
+		Health:      NewHealthRepository(db),
+		FeatureFlag: NewFeatureFlagRepository(db),
 	}, nil
 }
+
+// queryEventHook records the number and duration of queries on the canonical log line of the request.
+type queryEventHook struct{}
+
+func (queryEventHook) BeforeQuery(ctx context.Context, _ *bun.QueryEvent) context.Context {
+	return ctx
+}
+
+func (queryEventHook) AfterQuery(ctx context.Context, event *bun.QueryEvent) {
+	kooctx.RecordQuery(ctx, time.Since(event.StartTime))
+}
@@ -4,9 +4,10 @@ package repo
 type Repositories struct {
 	DB DatabaseConnection
 
-	User   KooUserRepository
-	Pet    KooPetRepository
-	Health HealthRepository
+	User        KooUserRepository
+	Pet         KooPetRepository
+	Health      HealthRepository
+	FeatureFlag FeatureFlagRepository
 }
 
 type DatabaseConnection interface {
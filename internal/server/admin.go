@@ -0,0 +1,182 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kootic/koogo/internal/config"
+)
+
+// adminReadHeaderTimeout bounds reading request headers on the admin listener. Request and response
+// timeouts are left unset so long-running pprof profiles and traces can complete.
+const adminReadHeaderTimeout = 10 * time.Second
+
+// AdminServer serves diagnostics and operational endpoints on a separate port that is not exposed
+// publicly: pprof, runtime stats, the redacted config, the route table and the log level.
+type AdminServer struct {
+	config     *config.Config
+	reloader   *config.Reloader
+	logLevel   zap.AtomicLevel
+	routes     []RouteInfo
+	logger     *zap.Logger
+	startedAt  time.Time
+	httpServer *http.Server
+}
+
+type runtimeStats struct {
+	GoVersion     string  `json:"goVersion"`
+	Version       string  `json:"version"`
+	UptimeSeconds float64 `json:"uptimeSeconds"`
+	NumCPU        int     `json:"numCpu"`
+	GOMAXPROCS    int     `json:"gomaxprocs"`
+	NumGoroutine  int     `json:"numGoroutine"`
+	HeapAlloc     uint64  `json:"heapAllocBytes"`
+	HeapInuse     uint64  `json:"heapInuseBytes"`
+	Sys           uint64  `json:"sysBytes"`
+	NumGC         uint32  `json:"numGc"`
+	PauseTotalNs  uint64  `json:"pauseTotalNs"`
+}
+
+// NewAdminServer creates the admin server reporting routes. logLevel is the level of
+// the application logger, which can be changed at runtime through the admin server.
+func NewAdminServer(
+	config *config.Config,
+	reloader *config.Reloader,
+	logLevel zap.AtomicLevel,
+	routes []RouteInfo,
+	logger *zap.Logger,
+) *AdminServer {
+	return &AdminServer{
+		config:    config,
+		reloader:  reloader,
+		logLevel:  logLevel,
+		routes:    routes,
+		logger:    logger,
+		startedAt: time.Now(),
+	}
+}
+
+func (s *AdminServer) handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.Handle("/debug/vars", expvar.Handler())
+	mux.HandleFunc("GET /debug/runtime", s.runtimeStats)
+	mux.HandleFunc("GET /config", s.effectiveConfig)
+	mux.HandleFunc("GET /routes", s.routeTable)
+	// GET returns the level, PUT {"level":"debug"} changes it until the next config reload
+	mux.Handle("/loglevel", s.logLevel)
+
+	return s.basicAuth(mux)
+}
+
+// basicAuth protects every admin endpoint with the admin credentials, which are required by config validation.
+func (s *AdminServer) basicAuth(next http.Handler) http.Handler {
+	username := []byte(s.config.App.AdminUsername)
+	password := []byte(s.config.App.AdminPassword.Reveal())
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		if !ok ||
+			subtle.ConstantTimeCompare([]byte(user), username) != 1 ||
+			subtle.ConstantTimeCompare([]byte(pass), password) != 1 {
+			w.Header().Set("WWW-Authenticate", `Basic realm="admin"`)
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *AdminServer) runtimeStats(w http.ResponseWriter, r *http.Request) {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	writeJSON(w, runtimeStats{
+		GoVersion:     runtime.Version(),
+		Version:       s.config.App.Version,
+		UptimeSeconds: time.Since(s.startedAt).Seconds(),
+		NumCPU:        runtime.NumCPU(),
+		GOMAXPROCS:    runtime.GOMAXPROCS(0),
+		NumGoroutine:  runtime.NumGoroutine(),
+		HeapAlloc:     mem.HeapAlloc,
+		HeapInuse:     mem.HeapInuse,
+		Sys:           mem.Sys,
+		NumGC:         mem.NumGC,
+		PauseTotalNs:  mem.PauseTotalNs,
+	})
+}
+
+// effectiveConfig returns the running config with the current runtime section. Secrets redact themselves.
+func (s *AdminServer) effectiveConfig(w http.ResponseWriter, r *http.Request) {
+	cfg := *s.config
+	cfg.Runtime = s.reloader.Runtime()
+
+	writeJSON(w, cfg)
+}
+
+func (s *AdminServer) routeTable(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, s.routes)
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// Start binds the admin host and port and serves in the background, so bind errors are returned immediately.
+func (s *AdminServer) Start(ctx context.Context) error {
+	var lc net.ListenConfig
+
+	address := net.JoinHostPort(s.config.App.AdminHost, strconv.Itoa(s.config.App.AdminPort))
+
+	listener, err := lc.Listen(ctx, "tcp", address)
+	if err != nil {
+		return fmt.Errorf("failed to listen on admin port: %w", err)
+	}
+
+	s.httpServer = &http.Server{
+		Handler:           s.handler(),
+		ReadHeaderTimeout: adminReadHeaderTimeout,
+	}
+
+	go func() {
+		if err := s.httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Error("Admin server failed", zap.Error(err))
+		}
+	}()
+
+	s.logger.Info("Admin server started", zap.String("address", address))
+
+	return nil
+}
+
+// Shutdown stops the admin server, waiting for in-flight requests until ctx is done.
+func (s *AdminServer) Shutdown(ctx context.Context) error {
+	if s.httpServer == nil {
+		return nil
+	}
+
+	return s.httpServer.Shutdown(ctx)
+}
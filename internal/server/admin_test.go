@@ -0,0 +1,91 @@
+package server
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/kootic/koogo/internal/config"
+)
+
+func newTestAdminServer() (*AdminServer, zap.AtomicLevel) {
+	cfg := &config.Config{App: config.AppConfig{
+		AdminUsername: "admin",
+		AdminPassword: "s3cret",
+	}}
+	logLevel := zap.NewAtomicLevelAt(zapcore.InfoLevel)
+
+	return NewAdminServer(cfg, config.NewReloader(cfg), logLevel, nil, zap.NewNop()), logLevel
+}
+
+func TestAdminServerAuth(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		username   string
+		password   string
+		path       string
+		wantStatus int
+	}{
+		{name: "no credentials", path: "/config", wantStatus: http.StatusUnauthorized},
+		{name: "wrong username", username: "root", password: "s3cret", path: "/config", wantStatus: http.StatusUnauthorized},
+		{name: "wrong password", username: "admin", password: "admin", path: "/config", wantStatus: http.StatusUnauthorized},
+		{name: "wrong credentials on pprof", username: "admin", password: "admin", path: "/debug/pprof/", wantStatus: http.StatusUnauthorized},
+		{name: "authorized", username: "admin", password: "s3cret", path: "/config", wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			admin, _ := newTestAdminServer()
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.username != "" {
+				req.SetBasicAuth(tt.username, tt.password)
+			}
+
+			rec := httptest.NewRecorder()
+			admin.handler().ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+
+			if tt.wantStatus == http.StatusUnauthorized && rec.Header().Get("WWW-Authenticate") == "" {
+				t.Fatal("expected a WWW-Authenticate challenge")
+			}
+
+			if strings.Contains(rec.Body.String(), "s3cret") {
+				t.Fatalf("secret leaked in response: %s", rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestAdminServerLogLevel(t *testing.T) {
+	t.Parallel()
+
+	admin, logLevel := newTestAdminServer()
+
+	req := httptest.NewRequest(http.MethodPut, "/loglevel", strings.NewReader(`{"level":"debug"}`))
+	req.SetBasicAuth("admin", "s3cret")
+
+	rec := httptest.NewRecorder()
+	admin.handler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		body, _ := io.ReadAll(rec.Body)
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, body)
+	}
+
+	if logLevel.Level() != zapcore.DebugLevel {
+		t.Fatalf("expected log level %s, got %s", zapcore.DebugLevel, logLevel.Level())
+	}
+}
@@ -0,0 +1,48 @@
+package server
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/kootic/koogo/internal/config"
+	"github.com/kootic/koogo/internal/repo"
+	"github.com/kootic/koogo/pkg/kooflag"
+)
+
+// newFlagEvaluator creates the feature flag evaluator and rebuilds its store whenever the runtime config is reloaded.
+func newFlagEvaluator(reloader *config.Reloader, repo repo.FeatureFlagRepository, logger *zap.Logger) (*kooflag.Evaluator, error) {
+	runtime := reloader.Runtime()
+
+	store, err := newFlagStore(runtime.FeatureFlags, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create feature flag store: %w", err)
+	}
+
+	evaluator := kooflag.NewEvaluator(store)
+
+	reloader.Subscribe(func(runtime config.RuntimeConfig) {
+		store, err := newFlagStore(runtime.FeatureFlags, repo)
+		if err != nil {
+			logger.Error("Failed to reload feature flags, keeping the previous flags", zap.Error(err))
+			return
+		}
+
+		evaluator.SetStore(store)
+	})
+
+	return evaluator, nil
+}
+
+func newFlagStore(cfg config.FeatureFlagsConfig, repo repo.FeatureFlagRepository) (kooflag.Store, error) {
+	if cfg.Store == config.FeatureFlagStorePostgres {
+		return kooflag.NewCachedStore(repo, cfg.CacheTTL), nil
+	}
+
+	flags, err := cfg.Flags()
+	if err != nil {
+		return nil, err
+	}
+
+	return kooflag.NewStaticStore(flags), nil
+}
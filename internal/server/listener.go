@@ -0,0 +1,102 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+
+	"go.uber.org/zap"
+
+	"github.com/kootic/koogo/pkg/kootls"
+)
+
+// socketMode allows the sidecar sharing the socket's group to connect.
+const socketMode = 0o660
+
+// listen creates the listener configured in AppConfig: a Unix socket or a TCP port, optionally with TLS.
+func (s *server) listen(ctx context.Context) (net.Listener, error) {
+	listener, err := s.listenNetwork(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.config.App.TLSEnabled() {
+		return listener, nil
+	}
+
+	tlsConfig, err := s.tlsConfig()
+	if err != nil {
+		_ = listener.Close()
+		return nil, err
+	}
+
+	return tls.NewListener(listener, tlsConfig), nil
+}
+
+func (s *server) listenNetwork(ctx context.Context) (net.Listener, error) {
+	var lc net.ListenConfig
+
+	if s.config.App.Socket == "" {
+		return lc.Listen(ctx, "tcp", fmt.Sprintf(":%d", s.config.App.Port))
+	}
+
+	if err := removeStaleSocket(s.config.App.Socket); err != nil {
+		return nil, err
+	}
+
+	listener, err := lc.Listen(ctx, "unix", s.config.App.Socket)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.Chmod(s.config.App.Socket, socketMode); err != nil {
+		_ = listener.Close()
+		return nil, fmt.Errorf("failed to set socket permissions: %w", err)
+	}
+
+	return listener, nil
+}
+
+// removeStaleSocket removes the socket left behind by a previous process, which would otherwise fail the
+// bind. Anything other than a socket at path is left alone, so a misconfigured path does not delete a file.
+func removeStaleSocket(path string) error {
+	fi, err := os.Lstat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to check stale socket: %w", err)
+	}
+
+	if fi.Mode()&fs.ModeSocket == 0 {
+		return fmt.Errorf("failed to remove stale socket: %s exists and is not a socket", path)
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to remove stale socket: %w", err)
+	}
+
+	return nil
+}
+
+// tlsConfig serves the configured certificates, which are reloaded when the files change.
+func (s *server) tlsConfig() (*tls.Config, error) {
+	reloader, err := kootls.NewReloader(kootls.Config{
+		CertFile:     s.config.App.TLSCert,
+		KeyFile:      s.config.App.TLSKey,
+		ClientCAFile: s.config.App.TLSClientCA,
+		OnReloadError: func(err error) {
+			s.logger.Error("Failed to reload TLS certificates, serving the previous certificates", zap.Error(err))
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS certificates: %w", err)
+	}
+
+	return reloader.TLSConfig(), nil
+}
@@ -0,0 +1,52 @@
+package server
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRemoveStaleSocket(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	socket := filepath.Join(dir, "stale.sock")
+
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatalf("failed to create socket: %v", err)
+	}
+
+	// Leave the socket file behind like a crashed process
+	listener.(*net.UnixListener).SetUnlinkOnClose(false)
+	_ = listener.Close()
+
+	file := filepath.Join(dir, "koogo.db")
+	if err := os.WriteFile(file, []byte("data"), 0o600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		wantErr    bool
+		wantExists bool
+	}{
+		{name: "stale socket is removed", path: socket},
+		{name: "missing path", path: filepath.Join(dir, "missing.sock")},
+		{name: "regular file is kept", path: file, wantErr: true, wantExists: true},
+	}
+
+	for _, tt := range tests {
+		err := removeStaleSocket(tt.path)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("%s: expected error %t, got %v", tt.name, tt.wantErr, err)
+		}
+
+		if _, err := os.Lstat(tt.path); (err == nil) != tt.wantExists {
+			t.Fatalf("%s: expected exists %t, got %v", tt.name, tt.wantExists, err)
+		}
+	}
+}
@@ -2,11 +2,18 @@ package middleware
 
 import (
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 
 	"github.com/kootic/koogo/pkg/kooctx"
 )
 
+const HeaderRequestID = "X-Request-ID"
+
+// maxRequestIDLength bounds request IDs accepted from clients, longer ones are replaced.
+const maxRequestIDLength = 128
+
 func InjectContext(logger *zap.Logger) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		c.SetUserContext(kooctx.SetContextLogger(c.UserContext(), logger))
@@ -14,3 +21,43 @@ func InjectContext(logger *zap.Logger) fiber.Handler {
 		return c.Next()
 	}
 }
+
+// RequestID correlates a request across logs, traces and responses. It accepts the X-Request-ID header
+// of the request or generates an ID, stores it in the context, adds it and the trace ID to the context
+// logger and echoes it in the X-Request-ID response header. It must run after InjectContext.
+func RequestID(c *fiber.Ctx) error {
+	requestID := c.Get(HeaderRequestID)
+	if !isValidRequestID(requestID) {
+		requestID = uuid.NewString()
+	}
+
+	c.Set(HeaderRequestID, requestID)
+
+	ctx := kooctx.SetContextRequestID(c.UserContext(), requestID)
+	fields := []zap.Field{zap.String("request_id", requestID)}
+
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.HasTraceID() {
+		fields = append(fields, zap.String("trace_id", spanContext.TraceID().String()))
+	}
+
+	ctx, _ = kooctx.WithLoggerFields(ctx, fields...)
+	c.SetUserContext(ctx)
+
+	return c.Next()
+}
+
+// isValidRequestID reports whether a client supplied request ID can be used as is: it must not be empty,
+// too long or contain characters other than printable ASCII, so it cannot forge log lines.
+func isValidRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+
+	for i := 0; i < len(requestID); i++ {
+		if requestID[i] < ' ' || requestID[i] > '~' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,89 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/kootic/koogo/internal/server/middleware"
+	"github.com/kootic/koogo/pkg/kooctx"
+	"github.com/kootic/koogo/pkg/koohttp"
+)
+
+func TestRequestID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		requestID    string
+		wantAccepted bool
+	}{
+		{name: "accepted", requestID: "req-123", wantAccepted: true},
+		{name: "longest accepted", requestID: strings.Repeat("a", 128), wantAccepted: true},
+		{name: "missing"},
+		{name: "too long", requestID: strings.Repeat("a", 129)},
+		{name: "non ascii", requestID: "réq"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			core, logs := observer.New(zapcore.DebugLevel)
+
+			app := fiber.New()
+			app.Use(
+				middleware.InjectContext(zap.New(core)),
+				middleware.RequestID,
+				middleware.CaptureError(koohttp.ErrorConfig{}),
+			)
+			app.Get("/", func(c *fiber.Ctx) error {
+				kooctx.GetContextLogger(c.UserContext()).Info("Handled")
+				return koohttp.ErrNotFound
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.requestID != "" {
+				req.Header.Set(middleware.HeaderRequestID, tt.requestID)
+			}
+
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer resp.Body.Close()
+
+			requestID := resp.Header.Get(middleware.HeaderRequestID)
+
+			if tt.wantAccepted {
+				if requestID != tt.requestID {
+					t.Fatalf("expected request ID %q to be echoed, got %q", tt.requestID, requestID)
+				}
+			} else if err := uuid.Validate(requestID); err != nil {
+				t.Fatalf("expected a generated UUID, got %q", requestID)
+			}
+
+			var body koohttp.APIResponseError
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if body.RequestID != requestID {
+				t.Fatalf("expected requestId %q in the error body, got %q", requestID, body.RequestID)
+			}
+
+			entries := logs.FilterMessage("Handled").AllUntimed()
+			if len(entries) != 1 || entries[0].ContextMap()["request_id"] != requestID {
+				t.Fatalf("expected request_id %q on the log line, got %+v", requestID, entries)
+			}
+		})
+	}
+}
@@ -3,17 +3,25 @@ package middleware
 import (
 	"errors"
 	"fmt"
-	"net/http"
 
 	"github.com/gofiber/fiber/v2"
-	"go.uber.org/zap"
 
 	"github.com/kootic/koogo/pkg/kooctx"
 	"github.com/kootic/koogo/pkg/koohttp"
 )
 
-func CaptureError(c *fiber.Ctx) error {
-	originalErr := c.Next()
+// CaptureError responds to errors returned by later handlers with an API error, represented as
+// configured by cfg, see koohttp.Error. Unexpected errors are hidden behind an internal server error and
+// added to the request's canonical log line, see LogRequestResponse.
+func CaptureError(cfg koohttp.ErrorConfig) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		koohttp.SetErrorConfig(c, cfg)
+
+		return captureError(c, c.Next())
+	}
+}
+
+func captureError(c *fiber.Ctx, originalErr error) error {
 	if originalErr == nil {
 		return nil
 	}
@@ -31,20 +39,19 @@ func CaptureError(c *fiber.Ctx) error {
 	// Handle our own API errors
 	ok = errors.As(originalErr, &apiErr)
 	if ok {
-		return c.Status(apiErr.HTTPStatus()).JSON(apiErr)
+		return koohttp.Error(c, apiErr)
 	}
 
 	// Anything else we wrap the original error in our own internal server error
-	apiErr = koohttp.NewAPIError(http.StatusInternalServerError, koohttp.APIErrorCodeInternalServerError)
+	apiErr = koohttp.ErrInternalServerError
 
-	respErr := c.Status(http.StatusInternalServerError).JSON(apiErr)
+	respErr := koohttp.Error(c, apiErr)
 	if respErr != nil {
 		return fmt.Errorf("failed to send internal server error response: %w: %w", respErr, apiErr)
 	}
 
-	// Log the error
-	logger := kooctx.GetContextLogger(c.UserContext())
-	logger.Error("Unexpected error", zap.Error(originalErr))
+	// The error is logged on the request's canonical log line
+	kooctx.AddField(c.UserContext(), "error", originalErr.Error())
 
 	return nil
 }
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/kootic/koogo/internal/config"
+	"github.com/kootic/koogo/pkg/kooctx"
+	"github.com/kootic/koogo/pkg/kooflag"
+)
+
+// FeatureFlags stores the evaluator and the request attributes flag rules match on in the user context,
+// so handlers and services can call kooflag.IsEnabled. Authentication should set the user ID with
+// kooctx.SetContextUserID once it is known.
+func FeatureFlags(evaluator *kooflag.Evaluator, env config.AppEnv) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		headers := make(map[string]string)
+		for name, values := range c.GetReqHeaders() {
+			if len(values) > 0 {
+				headers[name] = values[0]
+			}
+		}
+
+		ctx := kooctx.SetContextFlagEvaluator(c.UserContext(), evaluator)
+		ctx = kooctx.SetContextAttributes(ctx, kooctx.Attributes{
+			Environment: string(env),
+			Headers:     headers,
+		})
+		c.SetUserContext(ctx)
+
+		return c.Next()
+	}
+}
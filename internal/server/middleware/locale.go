@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/kootic/koogo/pkg/kooctx"
+	"github.com/kootic/koogo/pkg/kooi18n"
+)
+
+// Locale stores the localizer of the locale that best matches the Accept-Language header in the user
+// context, so error messages are translated and handlers and services can call kooi18n.Translate. The
+// locale is reported in the Content-Language response header.
+func Locale(bundle *kooi18n.Bundle) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		localizer := bundle.Localizer(c.Get(fiber.HeaderAcceptLanguage))
+
+		c.Vary(fiber.HeaderAcceptLanguage)
+		c.Set(fiber.HeaderContentLanguage, localizer.Locale())
+		c.SetUserContext(kooctx.SetContextTranslator(c.UserContext(), localizer))
+
+		return c.Next()
+	}
+}
@@ -1,54 +1,117 @@
 package middleware
 
 import (
-	"fmt"
+	"errors"
+	"math/rand/v2"
+	"path"
 	"slices"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
 	"go.uber.org/zap"
 
+	"github.com/kootic/koogo/internal/config"
 	"github.com/kootic/koogo/pkg/kooctx"
 )
 
-var ignorePaths = []string{
-	"/api/v1/health",
-}
+// LogRequestResponse emits one canonical log line per request, which handlers and services enrich with
+// kooctx.AddField, e.g. with the user ID. The line is logged at info level, or error level for server
+// errors, by the context logger, which adds the request and trace IDs.
+//
+// Server errors are always logged. Other requests are not logged when their path matches a skip
+// pattern or LogRequests is disabled, and successful requests are sampled. Headers and bodies are
+// redacted and truncated as configured by the runtime config, which can be reloaded.
+func LogRequestResponse(reloader *config.Reloader) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		startTime := time.Now()
+
+		// Later middleware may rewrite the path, e.g. APIVersion
+		requestPath := utils.CopyString(c.Path())
+
+		event := &kooctx.Event{}
+		c.SetUserContext(kooctx.SetContextEvent(c.UserContext(), event))
+
+		err := c.Next()
+
+		runtime := reloader.Runtime()
+		statusCode := responseStatus(c, err)
+		isServerError := statusCode >= fiber.StatusInternalServerError
+
+		if !isServerError && !shouldLog(requestPath, statusCode, runtime) {
+			return err
+		}
+
+		fields := []zap.Field{
+			zap.String("method", c.Method()),
+			zap.String("path", requestPath),
+			zap.String("route", c.Route().Path),
+			zap.Int("status", statusCode),
+			zap.Float64("latency_ms", float64(time.Since(startTime).Microseconds())/1000.0),
+		}
+
+		fields = append(fields, event.Fields()...)
+		fields = append(fields,
+			zap.Any("params", c.AllParams()),
+			zap.Any("queries", redactQueries(c.Queries(), runtime.RequestLog.RedactFields)),
+			zap.Any("headers", redactHeaders(c.GetReqHeaders(), runtime.RequestLog.RedactHeaders)),
+		)
+
+		if runtime.LogRequestBodies {
+			if body, ok := logBody(c.Body(), c.Get(fiber.HeaderContentType), runtime.RequestLog); ok {
+				fields = append(fields, zap.String("request_body", body))
+			}
+
+			if body, ok := logBody(c.Response().Body(), string(c.Response().Header.ContentType()), runtime.RequestLog); ok {
+				fields = append(fields, zap.String("response_body", body))
+			}
+		}
 
-func LogRequestResponse(c *fiber.Ctx) error {
-	startTime := time.Now()
+		fields = append(fields, zap.Error(err))
+		logger := kooctx.GetContextLogger(c.UserContext())
 
-	err := c.Next()
+		if isServerError {
+			logger.Error("Request", fields...)
+		} else {
+			logger.Info("Request", fields...)
+		}
 
-	url := c.OriginalURL()
-	if slices.Contains(ignorePaths, url) {
 		return err
 	}
+}
+
+// responseStatus returns the status the request is responded with. Errors returned to the logger have not
+// been handled by fiber's error handler yet, which responds with the code of fiber errors, e.g. 404 for
+// unknown routes, and 500 for other errors.
+func responseStatus(c *fiber.Ctx, err error) int {
+	var fiberErr *fiber.Error
+
+	switch {
+	case err == nil:
+		return c.Response().StatusCode()
+	case errors.As(err, &fiberErr):
+		return fiberErr.Code
+	default:
+		return fiber.StatusInternalServerError
+	}
+}
+
+// shouldLog reports whether a request that did not fail with a server error is logged.
+func shouldLog(requestPath string, statusCode int, runtime config.RuntimeConfig) bool {
+	if !runtime.LogRequests {
+		return false
+	}
+
+	if slices.ContainsFunc(runtime.RequestLog.SkipPaths, func(pattern string) bool {
+		matched, _ := path.Match(pattern, requestPath)
+		return matched
+	}) {
+		return false
+	}
 
-	statusCode := c.Response().StatusCode()
-	params := c.AllParams()
-	queries := c.Queries()
-	headers := c.GetReqHeaders()
-	requestBody := string(c.Body())
-	responseBody := string(c.Response().Body())
-	latencyMs := float64(time.Since(startTime).Microseconds()) / 1000.0
-
-	logger := kooctx.GetContextLogger(c.UserContext()).With(
-		zap.String("method", c.Method()),
-		zap.Int("status", statusCode),
-		zap.Any("params", params),
-		zap.Any("queries", queries),
-		zap.Float64("latency_ms", latencyMs),
-		zap.Any("headers", headers),
-		zap.Any("request_body", requestBody),
-		zap.Any("response_body", responseBody),
-	)
-
-	if statusCode >= 500 || err != nil {
-		logger.Error("Internal server error", zap.Error(err))
-	} else {
-		logger.Debug(fmt.Sprintf("%s %s", c.Method(), url))
+	if statusCode < 400 {
+		return rand.Float64() < runtime.RequestLog.SuccessSampleRate //nolint:gosec // Sampling does not need a secure source
 	}
 
-	return err
+	return true
 }
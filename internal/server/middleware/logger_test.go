@@ -0,0 +1,162 @@
+package middleware_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/kootic/koogo/internal/config"
+	"github.com/kootic/koogo/internal/server/middleware"
+	"github.com/kootic/koogo/pkg/koohttp"
+)
+
+func TestLogRequestResponse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		path       string
+		sampleRate float64
+		wantLevel  zapcore.Level
+		wantStatus int64
+		wantLogged bool
+	}{
+		{
+			name:       "success",
+			path:       "/api/v1/ok",
+			sampleRate: 1,
+			wantLevel:  zapcore.InfoLevel,
+			wantStatus: http.StatusOK,
+			wantLogged: true,
+		},
+		{
+			name: "sampled out success",
+			path: "/api/v1/ok",
+		},
+		{
+			name:       "client error is not sampled",
+			path:       "/api/v1/bad",
+			wantLevel:  zapcore.InfoLevel,
+			wantStatus: http.StatusBadRequest,
+			wantLogged: true,
+		},
+		{
+			name:       "unknown route",
+			path:       "/api/v1/unknown",
+			wantLevel:  zapcore.InfoLevel,
+			wantStatus: http.StatusNotFound,
+			wantLogged: true,
+		},
+		{
+			name:       "server error",
+			path:       "/api/v1/fail",
+			wantLevel:  zapcore.ErrorLevel,
+			wantStatus: http.StatusInternalServerError,
+			wantLogged: true,
+		},
+		{
+			name:       "skipped path",
+			path:       "/api/v1/health",
+			sampleRate: 1,
+		},
+		{
+			name:       "server error on a skipped path",
+			path:       "/api/v2/health",
+			wantLevel:  zapcore.ErrorLevel,
+			wantStatus: http.StatusInternalServerError,
+			wantLogged: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := &config.Config{Runtime: config.RuntimeConfig{
+				LogRequests: true,
+				RequestLog: config.RequestLogConfig{
+					SkipPaths:         []string{"/api/v*/health"},
+					SuccessSampleRate: tt.sampleRate,
+				},
+			}}
+			core, logs := observer.New(zapcore.DebugLevel)
+
+			app := fiber.New()
+			app.Use(
+				middleware.InjectContext(zap.New(core)),
+				middleware.LogRequestResponse(config.NewReloader(cfg)),
+				middleware.CaptureError(koohttp.ErrorConfig{}),
+			)
+			app.Get("/api/v1/ok", func(c *fiber.Ctx) error { return koohttp.Success(c, nil) })
+			app.Get("/api/v1/bad", func(c *fiber.Ctx) error { return koohttp.ErrBadRequest })
+			app.Get("/api/v1/fail", func(c *fiber.Ctx) error { return errors.New("boom") })
+			app.Get("/api/v1/health", func(c *fiber.Ctx) error { return koohttp.Success(c, nil) })
+			app.Get("/api/v2/health", func(c *fiber.Ctx) error { return errors.New("boom") })
+
+			if _, err := app.Test(httptest.NewRequest(http.MethodGet, tt.path, nil)); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			entries := logs.FilterMessage("Request").AllUntimed()
+			if !tt.wantLogged {
+				if len(entries) != 0 {
+					t.Fatalf("expected no log line, got %+v", entries)
+				}
+
+				return
+			}
+
+			if logs.Len() != 1 || len(entries) != 1 {
+				t.Fatalf("expected one request log line, got %+v", logs.AllUntimed())
+			}
+
+			if entries[0].Level != tt.wantLevel {
+				t.Fatalf("expected level %s, got %s", tt.wantLevel, entries[0].Level)
+			}
+
+			if status := entries[0].ContextMap()["status"]; status != tt.wantStatus {
+				t.Fatalf("expected status %d, got %v", tt.wantStatus, status)
+			}
+		})
+	}
+}
+
+func TestLogRequestResponseRedactsQueries(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Config{Runtime: config.RuntimeConfig{
+		LogRequests: true,
+		RequestLog: config.RequestLogConfig{
+			RedactFields:      []string{"token", "key"},
+			SuccessSampleRate: 1,
+		},
+	}}
+	core, logs := observer.New(zapcore.DebugLevel)
+
+	app := fiber.New()
+	app.Use(
+		middleware.InjectContext(zap.New(core)),
+		middleware.LogRequestResponse(config.NewReloader(cfg)),
+	)
+	app.Get("/", func(c *fiber.Ctx) error { return koohttp.Success(c, nil) })
+
+	req := httptest.NewRequest(http.MethodGet, "/?api_key=secret&Token=secret&page=2", nil)
+	if _, err := app.Test(req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	queries, _ := logs.All()[0].ContextMap()["queries"].(map[string]string)
+	want := map[string]string{"api_key": "[REDACTED]", "Token": "[REDACTED]", "page": "2"}
+
+	for name, value := range want {
+		if queries[name] != value {
+			t.Fatalf("expected queries %v, got %v", want, queries)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"sync/atomic"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/limiter"
+
+	"github.com/kootic/koogo/internal/config"
+	"github.com/kootic/koogo/pkg/koohttp"
+)
+
+// RateLimit limits the number of requests per client IP according to the runtime config.
+// The limiter is rebuilt whenever the limits are reloaded, which resets the request counters.
+func RateLimit(reloader *config.Reloader) fiber.Handler {
+	runtime := reloader.Runtime()
+	limit, window := runtime.RateLimit, runtime.RateLimitWindow
+
+	var current atomic.Pointer[fiber.Handler]
+
+	handler := newLimiter(runtime)
+	current.Store(&handler)
+
+	// Subscribers are called sequentially, so limit and window need no further synchronization
+	reloader.Subscribe(func(runtime config.RuntimeConfig) {
+		if runtime.RateLimit == limit && runtime.RateLimitWindow == window {
+			return
+		}
+
+		limit, window = runtime.RateLimit, runtime.RateLimitWindow
+
+		handler := newLimiter(runtime)
+		current.Store(&handler)
+	})
+
+	return func(c *fiber.Ctx) error {
+		return (*current.Load())(c)
+	}
+}
+
+func newLimiter(runtime config.RuntimeConfig) fiber.Handler {
+	if runtime.RateLimit <= 0 {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+
+	return limiter.New(limiter.Config{
+		Max:        runtime.RateLimit,
+		Expiration: runtime.RateLimitWindow,
+		LimitReached: func(c *fiber.Ctx) error {
+			return koohttp.TooManyRequests(c)
+		},
+	})
+}
@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/kootic/koogo/pkg/kooctx"
+	"github.com/kootic/koogo/pkg/koohttp"
+)
+
+// Recover converts panics in every handler registered after it, middleware included, into an internal
+// server error response. It must run directly after InjectContext and RequestID so the log and response
+// identify the request.
+//
+// It is the backstop for panics in the middleware before RecoverError: a panic it recovers unwinds
+// LogRequestResponse, so it is not on the request's canonical log line, and the response is only
+// translated and represented as configured when Locale and CaptureError ran before the panic.
+func Recover() fiber.Handler {
+	panics := newPanicRecorder()
+
+	return func(c *fiber.Ctx) (err error) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				panics.record(c, recovered)
+				err = koohttp.Error(c, koohttp.ErrInternalServerError)
+			}
+		}()
+
+		return c.Next()
+	}
+}
+
+// RecoverError converts panics in later handlers into a returned koohttp.ErrInternalServerError. It must
+// run directly after CaptureError, which responds to the error, so the panic is on the request's
+// canonical log line with status 500, see LogRequestResponse.
+func RecoverError() fiber.Handler {
+	panics := newPanicRecorder()
+
+	return func(c *fiber.Ctx) (err error) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				panicErr := panics.record(c, recovered)
+				kooctx.AddField(c.UserContext(), "error", panicErr.Error())
+				err = koohttp.ErrInternalServerError
+			}
+		}()
+
+		return c.Next()
+	}
+}
+
+// panicRecorder logs recovered panics with their stack trace by the context logger, records them on the
+// active span and counts them by the http.server.panics metric.
+type panicRecorder struct {
+	panics metric.Int64Counter
+}
+
+func newPanicRecorder() *panicRecorder {
+	panics, err := otel.Meter("github.com/kootic/koogo/internal/server/middleware").Int64Counter(
+		"http.server.panics",
+		metric.WithDescription("Number of requests that panicked"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return &panicRecorder{panics: panics}
+}
+
+// record reports the recovered panic and returns it as an error.
+func (p *panicRecorder) record(c *fiber.Ctx, recovered any) error {
+	ctx := c.UserContext()
+	panicErr := fmt.Errorf("panic: %v", recovered)
+	stack := debug.Stack()
+
+	kooctx.GetContextLogger(ctx).Error("Recovered from panic",
+		zap.Error(panicErr),
+		zap.ByteString("stack", stack),
+	)
+
+	span := trace.SpanFromContext(ctx)
+	span.RecordError(panicErr, trace.WithAttributes(attribute.String("exception.stacktrace", string(stack))))
+	span.SetStatus(codes.Error, panicErr.Error())
+
+	if p.panics != nil {
+		p.panics.Add(ctx, 1, metric.WithAttributes(
+			attribute.String("http.request.method", c.Method()),
+			attribute.String("http.route", c.Route().Path),
+		))
+	}
+
+	return panicErr
+}
@@ -0,0 +1,129 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/kootic/koogo/internal/config"
+	"github.com/kootic/koogo/internal/server/middleware"
+	"github.com/kootic/koogo/pkg/koohttp"
+)
+
+func TestRecover(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		handler fiber.Handler
+	}{
+		{
+			name:    "panic in handler",
+			handler: middleware.CaptureError(koohttp.ErrorConfig{}),
+		},
+		{
+			name:    "panic in middleware",
+			handler: func(*fiber.Ctx) error { panic("boom") },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			core, logs := observer.New(zapcore.DebugLevel)
+
+			app := fiber.New()
+			app.Use(
+				middleware.InjectContext(zap.New(core)),
+				middleware.RequestID,
+				middleware.Recover(),
+				tt.handler,
+			)
+			app.Get("/", func(*fiber.Ctx) error { panic("boom") })
+
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/", nil))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != http.StatusInternalServerError {
+				t.Fatalf("expected status %d, got %d", http.StatusInternalServerError, resp.StatusCode)
+			}
+
+			var body koohttp.APIResponseError
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if body.ErrorCode != koohttp.APIErrorCodeInternalServerError {
+				t.Fatalf("expected error code %s, got %s", koohttp.APIErrorCodeInternalServerError, body.ErrorCode)
+			}
+
+			if body.RequestID == "" || body.RequestID != resp.Header.Get(middleware.HeaderRequestID) {
+				t.Fatalf("expected request ID %q, got %q", resp.Header.Get(middleware.HeaderRequestID), body.RequestID)
+			}
+
+			if logs.FilterMessage("Recovered from panic").Len() != 1 {
+				t.Fatalf("expected the panic to be logged, got %+v", logs.AllUntimed())
+			}
+		})
+	}
+}
+
+func TestRecoverError(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Config{Runtime: config.RuntimeConfig{LogRequests: true}}
+	core, logs := observer.New(zapcore.DebugLevel)
+
+	app := fiber.New()
+	app.Use(
+		middleware.InjectContext(zap.New(core)),
+		middleware.RequestID,
+		middleware.Recover(),
+		middleware.LogRequestResponse(config.NewReloader(cfg)),
+		middleware.CaptureError(koohttp.ErrorConfig{}),
+		middleware.RecoverError(),
+	)
+	app.Get("/", func(*fiber.Ctx) error { panic("boom") })
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/", nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("expected status %d, got %d", http.StatusInternalServerError, resp.StatusCode)
+	}
+
+	entries := logs.FilterMessage("Request").AllUntimed()
+	if len(entries) != 1 {
+		t.Fatalf("expected one request log line, got %+v", logs.AllUntimed())
+	}
+
+	fields := entries[0].ContextMap()
+	want := map[string]any{
+		"status":     int64(http.StatusInternalServerError),
+		"error_code": koohttp.APIErrorCodeInternalServerError,
+		"error":      "panic: boom",
+	}
+
+	for key, value := range want {
+		if fields[key] != value {
+			t.Fatalf("expected %s %v on the request log line, got %v", key, value, fields[key])
+		}
+	}
+
+	if entries[0].Level != zapcore.ErrorLevel {
+		t.Fatalf("expected level %s, got %s", zapcore.ErrorLevel, entries[0].Level)
+	}
+}
@@ -0,0 +1,134 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/kootic/koogo/internal/config"
+)
+
+const redacted = "[REDACTED]"
+
+// redactHeaders returns a copy of headers with the values of the headers named in names redacted.
+func redactHeaders(headers map[string][]string, names []string) map[string][]string {
+	redactedHeaders := make(map[string][]string, len(headers))
+
+	for name, values := range headers {
+		if slices.ContainsFunc(names, func(n string) bool { return strings.EqualFold(n, name) }) {
+			values = []string{redacted}
+		}
+
+		redactedHeaders[name] = values
+	}
+
+	return redactedHeaders
+}
+
+// redactQueries returns a copy of queries with the values of the params whose name contains any of fields
+// redacted, the same way as JSON body fields, e.g. ?api_key=.
+func redactQueries(queries map[string]string, fields []string) map[string]string {
+	redactedQueries := make(map[string]string, len(queries))
+
+	for name, value := range queries {
+		if isRedactedField(name, fields) {
+			value = redacted
+		}
+
+		redactedQueries[name] = value
+	}
+
+	return redactedQueries
+}
+
+// logBody returns body as it should be logged, with JSON fields redacted and truncated to the
+// configured size. It returns false when the body is not logged: when it is empty, its content type is
+// not allowed, e.g. binary bodies, or it is JSON that cannot be redacted.
+func logBody(body []byte, contentType string, cfg config.RequestLogConfig) (string, bool) {
+	if len(body) == 0 {
+		return "", false
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = http.DetectContentType(body)
+		mediaType, _, _ = strings.Cut(mediaType, ";")
+	}
+
+	if !slices.ContainsFunc(cfg.BodyContentTypes, func(pattern string) bool {
+		matched, _ := path.Match(strings.ToLower(pattern), mediaType)
+		return matched
+	}) {
+		return "", false
+	}
+
+	if mediaType == fiber.MIMEApplicationJSON || strings.HasSuffix(mediaType, "+json") {
+		var ok bool
+		if body, ok = redactJSON(body, cfg.RedactFields); !ok {
+			return "", false
+		}
+	}
+
+	if cfg.BodyMaxBytes > 0 && len(body) > cfg.BodyMaxBytes {
+		return fmt.Sprintf("%s...[truncated %d bytes]", body[:cfg.BodyMaxBytes], len(body)-cfg.BodyMaxBytes), true
+	}
+
+	return string(body), true
+}
+
+// redactJSON redacts the values of fields whose name contains any of fields, at any depth. It returns
+// false for bodies that are not valid JSON, as they cannot be redacted.
+func redactJSON(body []byte, fields []string) ([]byte, bool) {
+	if len(fields) == 0 {
+		return body, true
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, false
+	}
+
+	redactedBody, err := json.Marshal(redactValue(value, fields))
+	if err != nil {
+		return nil, false
+	}
+
+	return redactedBody, true
+}
+
+func redactValue(value any, fields []string) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if isRedactedField(key, fields) {
+				v[key] = redacted
+			} else {
+				v[key] = redactValue(field, fields)
+			}
+		}
+	case []any:
+		for i, elem := range v {
+			v[i] = redactValue(elem, fields)
+		}
+	}
+
+	return value
+}
+
+func isRedactedField(key string, fields []string) bool {
+	key = strings.ToLower(key)
+
+	return slices.ContainsFunc(fields, func(field string) bool {
+		return strings.Contains(key, strings.ToLower(field))
+	})
+}
@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/limiter"
+
+	"github.com/kootic/koogo/pkg/koohttp"
+)
+
+// Timeout sets a deadline on the user context of the request. Handlers that fail after the deadline
+// has passed respond with a gateway timeout, as the server, not the client, ran out of time.
+func Timeout(timeout time.Duration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx, cancel := context.WithTimeout(c.UserContext(), timeout)
+		defer cancel()
+
+		c.SetUserContext(ctx)
+
+		err := c.Next()
+		if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return koohttp.ErrGatewayTimeout
+		}
+
+		return err
+	}
+}
+
+// BodyLimit rejects requests with a body larger than limit bytes. The server wide limit still applies,
+// so limit can only lower it.
+func BodyLimit(limit int) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if len(c.Body()) > limit {
+			return koohttp.PayloadTooLarge(c)
+		}
+
+		return c.Next()
+	}
+}
+
+// Deprecation adds the Deprecation (RFC 9745), Sunset (RFC 8594) and Link headers to responses of a
+// deprecated route. sunset and link are optional.
+func Deprecation(since, sunset time.Time, link string) fiber.Handler {
+	deprecation := "@" + strconv.FormatInt(since.Unix(), 10)
+
+	return func(c *fiber.Ctx) error {
+		c.Set("Deprecation", deprecation)
+
+		if !sunset.IsZero() {
+			c.Set("Sunset", sunset.UTC().Format(http.TimeFormat))
+		}
+
+		if link != "" {
+			c.Append(fiber.HeaderLink, fmt.Sprintf(`<%s>; rel="deprecation"`, link))
+		}
+
+		return c.Next()
+	}
+}
+
+// RouteRateLimit limits requests per client IP to a single route, on top of the global RateLimit.
+func RouteRateLimit(route string, limit int, window time.Duration) fiber.Handler {
+	return limiter.New(limiter.Config{
+		Max:        limit,
+		Expiration: window,
+		KeyGenerator: func(c *fiber.Ctx) string {
+			return route + ":" + c.IP()
+		},
+		LimitReached: func(c *fiber.Ctx) error {
+			return koohttp.TooManyRequests(c)
+		},
+	})
+}
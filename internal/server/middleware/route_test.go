@@ -0,0 +1,110 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/kootic/koogo/internal/server/middleware"
+	"github.com/kootic/koogo/pkg/koohttp"
+)
+
+func TestRoutePolicies(t *testing.T) {
+	t.Parallel()
+
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	sunset := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	// waitForDeadline fails once the deadline of the request context has passed, like a slow query
+	waitForDeadline := func(c *fiber.Ctx) error {
+		<-c.UserContext().Done()
+		return c.UserContext().Err()
+	}
+
+	tests := []struct {
+		name        string
+		handlers    []fiber.Handler
+		body        string
+		wantStatus  int
+		wantHeaders map[string]string
+	}{
+		{
+			name:       "handler exceeding the timeout",
+			handlers:   []fiber.Handler{middleware.Timeout(10 * time.Millisecond), waitForDeadline},
+			wantStatus: http.StatusGatewayTimeout,
+		},
+		{
+			name: "handler failing within the timeout",
+			handlers: []fiber.Handler{middleware.Timeout(time.Minute), func(*fiber.Ctx) error {
+				return koohttp.ErrConflict
+			}},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:       "body within the limit",
+			handlers:   []fiber.Handler{middleware.BodyLimit(4), okHandler},
+			body:       "1234",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "body over the limit",
+			handlers:   []fiber.Handler{middleware.BodyLimit(4), okHandler},
+			body:       "12345",
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:       "deprecated route",
+			handlers:   []fiber.Handler{middleware.Deprecation(since, sunset, "https://example.com/v2"), okHandler},
+			wantStatus: http.StatusOK,
+			wantHeaders: map[string]string{
+				"Deprecation": "@1767225600",
+				"Sunset":      "Wed, 01 Jul 2026 00:00:00 GMT",
+				"Link":        `<https://example.com/v2>; rel="deprecation"`,
+			},
+		},
+		{
+			name:       "deprecated route without sunset and link",
+			handlers:   []fiber.Handler{middleware.Deprecation(since, time.Time{}, ""), okHandler},
+			wantStatus: http.StatusOK,
+			wantHeaders: map[string]string{
+				"Deprecation": "@1767225600",
+				"Sunset":      "",
+				"Link":        "",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			app := fiber.New()
+			app.Use(middleware.CaptureError(koohttp.ErrorConfig{}))
+			app.Post("/", tt.handlers...)
+
+			resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body)))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, resp.StatusCode)
+			}
+
+			for header, want := range tt.wantHeaders {
+				if got := resp.Header.Get(header); got != want {
+					t.Fatalf("expected %s header %q, got %q", header, want, got)
+				}
+			}
+		})
+	}
+}
+
+func okHandler(c *fiber.Ctx) error {
+	return koohttp.Success(c, nil)
+}
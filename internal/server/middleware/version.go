@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/kootic/koogo/pkg/koohttp"
+)
+
+const (
+	HeaderAcceptVersion = "Accept-Version"
+	HeaderAPIVersion    = "API-Version"
+)
+
+// versionedPath matches the version segment of a versioned API path, e.g. "/v1" in /api/v1/health.
+var versionedPath = regexp.MustCompile(`^/v(\d+)(/|$)`)
+
+// APIVersion serves requests to unversioned paths under basePath, e.g. /api/health, from the version
+// selected by the Accept-Version header ("2" or "v2"), or from the latest version when it is not set.
+// The version serving the request is reported in the API-Version response header.
+func APIVersion(basePath string, versions []int) fiber.Handler {
+	latest := strconv.Itoa(slices.Max(versions))
+
+	return func(c *fiber.Ctx) error {
+		rest, ok := strings.CutPrefix(c.Path(), basePath)
+		if !ok || (rest != "" && !strings.HasPrefix(rest, "/")) {
+			return c.Next()
+		}
+
+		if match := versionedPath.FindStringSubmatch(rest); match != nil {
+			c.Set(HeaderAPIVersion, match[1])
+			return c.Next()
+		}
+
+		c.Vary(HeaderAcceptVersion)
+
+		version := latest
+		if accept := c.Get(HeaderAcceptVersion); accept != "" {
+			number, err := strconv.Atoi(strings.TrimPrefix(strings.ToLower(strings.TrimSpace(accept)), "v"))
+			if err != nil || !slices.Contains(versions, number) {
+				return koohttp.BadRequest(c)
+			}
+
+			version = strconv.Itoa(number)
+		}
+
+		c.Set(HeaderAPIVersion, version)
+		c.Path(basePath + "/v" + version + rest)
+
+		return c.Next()
+	}
+}
@@ -1,48 +1,277 @@
 package server
 
 import (
+	"cmp"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"slices"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
+
+	"github.com/kootic/koogo/internal/server/middleware"
 )
 
 const (
 	APIBasePath = "/api"
 )
 
+// AuthPolicy names the authentication a route requires. Policies other than AuthPublic must be
+// registered in server.authPolicies with the middleware that enforces them.
+type AuthPolicy string
+
+const (
+	AuthPublic AuthPolicy = "public"
+)
+
+// RateLimitPolicy limits requests per client IP to a route, on top of the global rate limit.
+type RateLimitPolicy struct {
+	Limit  int           `json:"limit"`
+	Window time.Duration `json:"window"`
+}
+
+// MarshalJSON encodes the window as a duration string, see Duration.
+func (p RateLimitPolicy) MarshalJSON() ([]byte, error) {
+	type policy RateLimitPolicy
+
+	return json.Marshal(struct {
+		policy
+		Window Duration `json:"window"`
+	}{policy(p), Duration(p.Window)})
+}
+
+// Duration is a time.Duration encoded as a string in JSON, e.g. "10s", so the route table stays readable
+// when it is diffed between releases.
+type Duration time.Duration
+
+func (d Duration) String() string {
+	return time.Duration(d).String()
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// Deprecation marks a route as deprecated, which is advertised with the Deprecation, Sunset and Link headers.
+type Deprecation struct {
+	Since  time.Time `json:"since"`
+	Sunset time.Time `json:"sunset,omitzero"`
+	// Link points at documentation of the replacement.
+	Link string `json:"link,omitempty"`
+}
+
 type route struct {
-	Version    int
-	Method     string
-	Path       string
+	// Name uniquely identifies the route, e.g. in logs, metrics and the route table.
+	Name    string
+	Version int
+	Method  string
+	Path    string
+	Tags    []string
+	// Auth defaults to AuthPublic.
+	Auth        AuthPolicy
+	RateLimit   *RateLimitPolicy
+	Timeout     time.Duration
+	BodyLimit   int // Body limit in bytes, lower than the server wide limit
+	Deprecation *Deprecation
+	// Supersedes names the route of an earlier version this route replaces from its version on.
+	Supersedes string
 	Middleware []fiber.Handler
 	Handler    fiber.Handler
+
+	// Set by expandVersions for the route served under each version
+	introduced   int
+	supersededBy string
 }
 
-func (s *server) allRoutes() []route {
-	return []route{
-		{
-			Version: 1,
-			Method:  http.MethodGet,
-			Path:    "/health",
-			Handler: s.handler.HealthHandler.HealthCheck,
-		},
-		{
-			Version: 1,
-			Method:  http.MethodPost,
-			Path:    "/koo/users",
-			Handler: s.handler.KooUserHandler.CreateUser,
-		},
+// routeGroup shares a path prefix, tags, middleware and policies between its routes. Policies set on a
+// route take precedence over the group's.
+type routeGroup struct {
+	Prefix      string
+	Tags        []string
+	Auth        AuthPolicy
+	RateLimit   *RateLimitPolicy
+	Timeout     time.Duration
+	BodyLimit   int
+	Deprecation *Deprecation
+	Middleware  []fiber.Handler
+	Routes      []route
+}
+
+// resolve returns the group's routes with the prefix, tags, middleware and policies of the group applied.
+func (g routeGroup) resolve() []route {
+	routes := make([]route, len(g.Routes))
+
+	for i, r := range g.Routes {
+		r.Path = g.Prefix + r.Path
+		r.Tags = slices.Concat(g.Tags, r.Tags)
+		r.Middleware = slices.Concat(g.Middleware, r.Middleware)
+
+		if r.Auth == "" {
+			r.Auth = cmp.Or(g.Auth, AuthPublic)
+		}
+
+		if r.RateLimit == nil {
+			r.RateLimit = g.RateLimit
+		}
+
+		if r.Timeout == 0 {
+			r.Timeout = g.Timeout
+		}
+
+		if r.BodyLimit == 0 {
+			r.BodyLimit = g.BodyLimit
+		}
+
+		if r.Deprecation == nil {
+			r.Deprecation = g.Deprecation
+		}
+
+		routes[i] = r
+	}
+
+	return routes
+}
+
+// RouteInfo is the metadata of a registered route, as reported by Server.Routes.
+type RouteInfo struct {
+	Name         string           `json:"name"`
+	Version      int              `json:"version"`
+	Introduced   int              `json:"introduced"` // Version the route is declared in
+	Supersedes   string           `json:"supersedes,omitempty"`
+	SupersededBy string           `json:"supersededBy,omitempty"`
+	Method       string           `json:"method"`
+	Path         string           `json:"path"`
+	Tags         []string         `json:"tags,omitempty"`
+	Auth         AuthPolicy       `json:"auth"`
+	RateLimit    *RateLimitPolicy `json:"rateLimit,omitempty"`
+	Timeout      Duration         `json:"timeout,omitempty"`
+	BodyLimit    int              `json:"bodyLimit,omitempty"`
+	Deprecation  *Deprecation     `json:"deprecation,omitempty"`
+}
+
+// fullPath returns the path the route is served at, e.g. /api/v1/health.
+func (r route) fullPath() string {
+	return fmt.Sprintf("%s/v%d%s", APIBasePath, r.Version, r.Path)
+}
+
+// versionedName returns the name of the route served under its version, e.g. koo.users.create.v2, which
+// stays unique when the route is carried over to later versions.
+func (r route) versionedName() string {
+	return fmt.Sprintf("%s.v%d", r.Name, r.Version)
+}
+
+func (r route) info() RouteInfo {
+	return RouteInfo{
+		Name:         r.Name,
+		Version:      r.Version,
+		Introduced:   r.introduced,
+		Supersedes:   r.Supersedes,
+		SupersededBy: r.supersededBy,
+		Method:       r.Method,
+		Path:         r.fullPath(),
+		Tags:         r.Tags,
+		Auth:         r.Auth,
+		RateLimit:    r.RateLimit,
+		Timeout:      Duration(r.Timeout),
+		BodyLimit:    r.BodyLimit,
+		Deprecation:  r.Deprecation,
+	}
+}
+
+// handlers returns the route's handler chain: the middleware enforcing its policies, then its own
+// middleware and handler.
+func (s *server) handlers(r route) ([]fiber.Handler, error) {
+	var handlers []fiber.Handler
+
+	if r.Deprecation != nil {
+		if r.Deprecation.Since.IsZero() {
+			return nil, fmt.Errorf("route %s: deprecation requires a since date", r.Name)
+		}
+
+		handlers = append(handlers, middleware.Deprecation(r.Deprecation.Since, r.Deprecation.Sunset, r.Deprecation.Link))
+	}
+
+	if r.Auth != AuthPublic {
+		auth, ok := s.authPolicies[r.Auth]
+		if !ok {
+			return nil, fmt.Errorf("route %s: unknown auth policy %q", r.Name, r.Auth)
+		}
+
+		handlers = append(handlers, auth)
+	}
+
+	if r.RateLimit != nil {
+		handlers = append(handlers, middleware.RouteRateLimit(r.Name, r.RateLimit.Limit, r.RateLimit.Window))
+	}
+
+	if r.BodyLimit > 0 {
+		handlers = append(handlers, middleware.BodyLimit(r.BodyLimit))
+	}
+
+	if r.Timeout > 0 {
+		handlers = append(handlers, middleware.Timeout(r.Timeout))
+	}
+
+	handlers = append(handlers, r.Middleware...)
+	handlers = append(handlers, r.Handler)
+
+	return handlers, nil
+}
+
+// allRoutes returns every API route with the policies of its group applied, once for each API version
+// it is served under.
+func (s *server) allRoutes() ([]route, error) {
+	var routes []route
+	for _, group := range s.routeGroups() {
+		routes = append(routes, group.resolve()...)
+	}
+
+	return expandVersions(routes, apiVersions())
+}
+
+func (s *server) routeGroups() []routeGroup {
+	return []routeGroup{
 		{
-			Version: 1,
-			Method:  http.MethodGet,
-			Path:    "/koo/users/:userId",
-			Handler: s.handler.KooUserHandler.GetUserByID,
+			Tags:    []string{"Health"},
+			Timeout: 10 * time.Second,
+			Routes: []route{
+				{
+					Name:    "health",
+					Version: 1,
+					Method:  http.MethodGet,
+					Path:    "/health",
+					Handler: s.handler.HealthHandler.HealthCheck,
+				},
+			},
 		},
 		{
-			Version: 1,
-			Method:  http.MethodGet,
-			Path:    "/koo/users/:userId/pet",
-			Handler: s.handler.KooUserHandler.GetUserPet,
+			Prefix:  "/koo/users",
+			Tags:    []string{"Users"},
+			Timeout: 30 * time.Second,
+			Routes: []route{
+				{
+					Name:      "koo.users.create",
+					Version:   1,
+					Method:    http.MethodPost,
+					BodyLimit: 64 * 1024,
+					Handler:   s.handler.KooUserHandler.CreateUser,
+				},
+				{
+					Name:    "koo.users.get",
+					Version: 1,
+					Method:  http.MethodGet,
+					Path:    "/:userId",
+					Handler: s.handler.KooUserHandler.GetUserByID,
+				},
+				{
+					Name:    "koo.users.pet.get",
+					Version: 1,
+					Method:  http.MethodGet,
+					Path:    "/:userId/pet",
+					Handler: s.handler.KooUserHandler.GetUserPet,
+				},
+			},
 		},
 	}
 }
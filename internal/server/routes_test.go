@@ -0,0 +1,142 @@
+package server
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestRouteInfoJSON(t *testing.T) {
+	t.Parallel()
+
+	info := RouteInfo{
+		Name:      "koo.users.create",
+		RateLimit: &RateLimitPolicy{Limit: 10, Window: time.Minute},
+		Timeout:   Duration(10 * time.Second),
+	}
+
+	data, err := json.Marshal(info)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got struct {
+		RateLimit map[string]any `json:"rateLimit"`
+		Timeout   any            `json:"timeout"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got.Timeout != "10s" || got.RateLimit["window"] != "1m0s" || got.RateLimit["limit"] != float64(10) {
+		t.Fatalf("expected durations encoded as strings, got %s", data)
+	}
+}
+
+func TestRouteGroupResolve(t *testing.T) {
+	t.Parallel()
+
+	groupLimit := &RateLimitPolicy{Limit: 100, Window: time.Minute}
+	routeLimit := &RateLimitPolicy{Limit: 10, Window: time.Minute}
+	groupDeprecation := &Deprecation{Since: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	routeDeprecation := &Deprecation{Since: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)}
+	noop := func(*fiber.Ctx) error { return nil }
+
+	group := routeGroup{
+		Prefix:      "/users",
+		Tags:        []string{"Users"},
+		Auth:        "user",
+		RateLimit:   groupLimit,
+		Timeout:     30 * time.Second,
+		BodyLimit:   1024,
+		Deprecation: groupDeprecation,
+		Middleware:  []fiber.Handler{noop},
+		Routes: []route{
+			{Name: "users.list"},
+			{
+				Name:        "users.create",
+				Path:        "/:id",
+				Tags:        []string{"Admin"},
+				Auth:        AuthPublic,
+				RateLimit:   routeLimit,
+				Timeout:     time.Second,
+				BodyLimit:   64,
+				Deprecation: routeDeprecation,
+				Middleware:  []fiber.Handler{noop},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		route          route
+		wantPath       string
+		wantTags       []string
+		wantAuth       AuthPolicy
+		wantRateLimit  *RateLimitPolicy
+		wantTimeout    time.Duration
+		wantBodyLimit  int
+		wantDeprecated *Deprecation
+		wantMiddleware int
+	}{
+		{
+			name:           "inherits the group's policies",
+			route:          group.resolve()[0],
+			wantPath:       "/users",
+			wantTags:       []string{"Users"},
+			wantAuth:       "user",
+			wantRateLimit:  groupLimit,
+			wantTimeout:    30 * time.Second,
+			wantBodyLimit:  1024,
+			wantDeprecated: groupDeprecation,
+			wantMiddleware: 1,
+		},
+		{
+			name:           "route policies take precedence",
+			route:          group.resolve()[1],
+			wantPath:       "/users/:id",
+			wantTags:       []string{"Users", "Admin"},
+			wantAuth:       AuthPublic,
+			wantRateLimit:  routeLimit,
+			wantTimeout:    time.Second,
+			wantBodyLimit:  64,
+			wantDeprecated: routeDeprecation,
+			wantMiddleware: 2,
+		},
+		{
+			name:           "auth defaults to public",
+			route:          routeGroup{Routes: []route{{Name: "health"}}}.resolve()[0],
+			wantAuth:       AuthPublic,
+			wantMiddleware: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := tt.route
+			if r.Path != tt.wantPath || !slices.Equal(r.Tags, tt.wantTags) || r.Auth != tt.wantAuth {
+				t.Fatalf("expected path %q, tags %v and auth %q, got %q, %v and %q",
+					tt.wantPath, tt.wantTags, tt.wantAuth, r.Path, r.Tags, r.Auth)
+			}
+
+			if r.RateLimit != tt.wantRateLimit || r.Deprecation != tt.wantDeprecated {
+				t.Fatalf("expected rate limit %v and deprecation %v, got %v and %v",
+					tt.wantRateLimit, tt.wantDeprecated, r.RateLimit, r.Deprecation)
+			}
+
+			if r.Timeout != tt.wantTimeout || r.BodyLimit != tt.wantBodyLimit {
+				t.Fatalf("expected timeout %s and body limit %d, got %s and %d",
+					tt.wantTimeout, tt.wantBodyLimit, r.Timeout, r.BodyLimit)
+			}
+
+			if len(r.Middleware) != tt.wantMiddleware {
+				t.Fatalf("expected %d middleware, got %d", tt.wantMiddleware, len(r.Middleware))
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package server
+
+import (
+	"reflect"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/kootic/koogo/internal/config"
+	"github.com/kootic/koogo/internal/handler"
+	"github.com/kootic/koogo/internal/service"
+	"github.com/kootic/koogo/pkg/koohealth"
+)
+
+// RouteEntry describes a route and its full handler chain, see RouteTable.
+type RouteEntry struct {
+	RouteInfo
+
+	Handler string `json:"handler"`
+	// Middleware lists the global middleware followed by the route's own, in the order they run.
+	Middleware []string `json:"middleware"`
+}
+
+// closureSuffix matches the suffix the compiler adds to closures and method values, e.g. ".func1" or "-fm".
+var closureSuffix = regexp.MustCompile(`(\.func\d+)+$|-fm$`)
+
+// RouteTable builds the table of API routes from their declarations without connecting to the database
+// or starting the server, e.g. to review changes to the API surface.
+func RouteTable(cfg *config.Config) ([]RouteEntry, error) {
+	// Handlers are only inspected, never called, so they do not need services
+	s := &server{
+		config:       cfg,
+		reloader:     config.NewReloader(cfg),
+		logger:       zap.NewNop(),
+		handler:      handler.NewHandler(&service.Services{}, koohealth.NewProbes()),
+		authPolicies: authPolicies(),
+	}
+
+	var global []string
+	for _, h := range s.middleware() {
+		global = append(global, funcName(h))
+	}
+
+	routes, err := s.allRoutes()
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []RouteEntry
+
+	for _, route := range routes {
+		handlers, err := s.handlers(route)
+		if err != nil {
+			return nil, err
+		}
+
+		middleware := append([]string{}, global...)
+		for _, h := range handlers[:len(handlers)-1] {
+			middleware = append(middleware, funcName(h))
+		}
+
+		entries = append(entries, RouteEntry{
+			RouteInfo:  route.info(),
+			Handler:    funcName(route.Handler),
+			Middleware: middleware,
+		})
+	}
+
+	return entries, nil
+}
+
+// funcName returns the short name of the function behind h, e.g. "handler.(*healthHandler).HealthCheck"
+// or "middleware.Timeout" for the handler returned by middleware.Timeout.
+func funcName(h any) string {
+	fn := runtime.FuncForPC(reflect.ValueOf(h).Pointer())
+	if fn == nil {
+		return "unknown"
+	}
+
+	name := fn.Name()
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+
+	return closureSuffix.ReplaceAllString(name, "")
+}
@@ -5,82 +5,190 @@ import (
 	"database/sql"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gofiber/contrib/otelfiber"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/basicauth"
 	"github.com/gofiber/swagger"
+	"github.com/swaggo/swag"
 	"go.uber.org/zap"
 
 	"github.com/kootic/koogo/internal/config"
 	"github.com/kootic/koogo/internal/handler"
+	"github.com/kootic/koogo/internal/locales"
 	"github.com/kootic/koogo/internal/repo/postgres"
 	"github.com/kootic/koogo/internal/server/middleware"
 	"github.com/kootic/koogo/internal/service"
+	"github.com/kootic/koogo/pkg/kooflag"
+	"github.com/kootic/koogo/pkg/koohealth"
+	"github.com/kootic/koogo/pkg/koohttp"
+	"github.com/kootic/koogo/pkg/kooi18n"
 )
 
 // Server represents the HTTP server interface.
 type Server interface {
 	Initialize() error
+	// Routes returns the metadata of the registered API routes.
+	Routes() []RouteInfo
 	Start() error
 	Shutdown(ctx context.Context) error
 }
 
 type server struct {
 	config        *config.Config
+	reloader      *config.Reloader
 	logger        *zap.Logger
 	handler       *handler.Handler
+	flags         *kooflag.Evaluator
+	probes        *koohealth.Probes
+	translations  *kooi18n.Bundle
+	authPolicies  map[AuthPolicy]fiber.Handler
+	routes        []RouteInfo
 	fiberApp      *fiber.App
 	isInitialized bool
 }
 
-func NewServer(config *config.Config, logger *zap.Logger, sqlDB *sql.DB, fiberApp *fiber.App) (*server, error) {
+func NewServer(
+	config *config.Config,
+	reloader *config.Reloader,
+	checks *koohealth.Registry,
+	logger *zap.Logger,
+	sqlDB *sql.DB,
+	fiberApp *fiber.App,
+) (*server, error) {
+	// Fail on error codes that are defined twice
+	if err := koohttp.CheckErrorCatalog(); err != nil {
+		return nil, err
+	}
+
 	// Create repositories
 	repos, err := postgres.NewRepositories(sqlDB)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create repositories: %w", err)
 	}
 
+	// Create feature flag evaluator
+	flags, err := newFlagEvaluator(reloader, repos.FeatureFlag, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	// Register health checks
+	if err := checks.Register(koohealth.Check{
+		Name:     "database",
+		Critical: true,
+		Check:    repos.Health.Ping,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to register health check: %w", err)
+	}
+
+	// Load the translations of error messages
+	translations, err := locales.NewBundle()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load translations: %w", err)
+	}
+
 	// Create services
-	services := service.NewServices(repos)
+	services := service.NewServices(repos, checks)
 
 	// Create handlers
-	handler := handler.NewHandler(services)
+	probes := koohealth.NewProbes()
+	handler := handler.NewHandler(services, probes)
 
 	return &server{
-		config:   config,
-		logger:   logger,
-		handler:  handler,
-		fiberApp: fiberApp,
+		config:       config,
+		reloader:     reloader,
+		logger:       logger,
+		handler:      handler,
+		flags:        flags,
+		probes:       probes,
+		translations: translations,
+		authPolicies: authPolicies(),
+		fiberApp:     fiberApp,
 	}, nil
 }
 
-func (s *server) RegisterMiddleware() {
-	s.fiberApp.Use(
+// authPolicies returns the middleware enforcing each auth policy used by routes, e.g. "user": requireUser.
+func authPolicies() map[AuthPolicy]fiber.Handler {
+	return map[AuthPolicy]fiber.Handler{}
+}
+
+// middleware returns the global middleware, which runs before every route's handlers.
+func (s *server) middleware() []fiber.Handler {
+	return []fiber.Handler{
 		otelfiber.Middleware(),
 		middleware.InjectContext(s.logger),
-		middleware.LogRequestResponse,
-		middleware.CaptureError,
-	)
+		middleware.RequestID,
+		middleware.Recover(),
+		middleware.Locale(s.translations),
+		middleware.FeatureFlags(s.flags, s.config.App.Env),
+		middleware.LogRequestResponse(s.reloader),
+		middleware.CaptureError(koohttp.ErrorConfig{
+			Format:      s.config.App.ErrorFormat,
+			TypeBaseURL: s.config.App.ErrorTypeBaseURL,
+		}),
+		middleware.RecoverError(),
+		middleware.RateLimit(s.reloader),
+		middleware.APIVersion(APIBasePath, versionNumbers(apiVersions())),
+	}
 }
 
-func (s *server) RegisterRoutes() {
-	for _, route := range s.allRoutes() {
-		s.fiberApp.Add(
-			route.Method,
-			fmt.Sprintf("%s/v%d%s", APIBasePath, route.Version, route.Path),
-			append(route.Middleware, route.Handler)...,
-		)
+func (s *server) RegisterMiddleware() {
+	for _, handler := range s.middleware() {
+		s.fiberApp.Use(handler)
 	}
 }
 
+// RegisterProbes registers the liveness, readiness and startup probes outside the versioned API.
+// They are registered before the middleware so probes are not logged, traced or rate limited.
+func (s *server) RegisterProbes() {
+	s.fiberApp.Get("/livez", s.handler.HealthHandler.Livez)
+	s.fiberApp.Get("/readyz", s.handler.HealthHandler.Readyz)
+	s.fiberApp.Get("/startupz", s.handler.HealthHandler.Startupz)
+
+	s.fiberApp.Hooks().OnListen(func(fiber.ListenData) error {
+		s.probes.MarkStarted()
+		return nil
+	})
+}
+
+// RegisterRoutes registers the API routes, applying the policies declared in their metadata.
+func (s *server) RegisterRoutes() error {
+	routes, err := s.allRoutes()
+	if err != nil {
+		return err
+	}
+
+	for _, route := range routes {
+		handlers, err := s.handlers(route)
+		if err != nil {
+			return err
+		}
+
+		s.fiberApp.Add(route.Method, route.fullPath(), handlers...).Name(route.versionedName())
+		s.routes = append(s.routes, route.info())
+	}
+
+	return nil
+}
+
+func (s *server) Routes() []RouteInfo {
+	return s.routes
+}
+
 func (s *server) RegisterSwagger() {
 	if !s.config.Swagger.Enabled {
 		return
 	}
 
+	registerSwaggerDoc.Do(func() {
+		swag.Register(swaggerInstanceName, newErrorCatalogDoc(swag.Name))
+	})
+
 	swaggerHandler := swagger.New(swagger.Config{
-		Title: "koogo API Docs",
+		Title:        "koogo API Docs",
+		InstanceName: swaggerInstanceName,
 	})
 
 	s.fiberApp.Add(
@@ -88,7 +196,7 @@ func (s *server) RegisterSwagger() {
 		"/swagger/*",
 		basicauth.New(basicauth.Config{
 			Users: map[string]string{
-				s.config.Swagger.Username: s.config.Swagger.Password,
+				s.config.Swagger.Username: s.config.Swagger.Password.Reveal(),
 			},
 		}),
 		swaggerHandler,
@@ -101,8 +209,12 @@ func (s *server) Initialize() error {
 		return fmt.Errorf("fiber app has not been created yet")
 	}
 
+	s.RegisterProbes()
 	s.RegisterMiddleware()
-	s.RegisterRoutes()
+	if err := s.RegisterRoutes(); err != nil {
+		return fmt.Errorf("failed to register routes: %w", err)
+	}
+
 	s.RegisterSwagger()
 
 	s.isInitialized = true
@@ -116,7 +228,13 @@ func (s *server) Start() error {
 		return fmt.Errorf("fiber app is not initialized")
 	}
 
-	if err := s.fiberApp.Listen(fmt.Sprintf(":%d", s.config.App.Port)); err != nil {
+	listener, err := s.listen(context.Background())
+	if err != nil {
+		s.logger.Error("Failed to listen", zap.Error(err))
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+
+	if err := s.fiberApp.Listener(listener); err != nil {
 		s.logger.Error("Failed to start server", zap.Error(err))
 		return fmt.Errorf("failed to start server: %w", err)
 	}
@@ -130,5 +248,33 @@ func (s *server) Shutdown(ctx context.Context) error {
 		return nil
 	}
 
+	// Fail readiness first and keep serving for the drain delay, so load balancers stop routing
+	// new requests before connections are closed
+	s.probes.MarkDraining()
+
+	if delay := drainDelay(ctx, s.config.App.DrainDelay); delay > 0 {
+		s.logger.Info("Draining before shutdown", zap.Duration("delay", delay))
+
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+		}
+	}
+
+	// Always shut down, even past the deadline, so listeners are closed before the components the
+	// handlers use, e.g. the database
 	return s.fiberApp.ShutdownWithContext(ctx)
 }
+
+// drainDelay caps delay at half of the time left before the deadline of ctx, leaving the rest to finish
+// in-flight requests.
+func drainDelay(ctx context.Context, delay time.Duration) time.Duration {
+	if deadline, ok := ctx.Deadline(); ok {
+		return min(delay, time.Until(deadline)/2)
+	}
+
+	return delay
+}
@@ -0,0 +1,78 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/kootic/koogo/internal/config"
+	"github.com/kootic/koogo/pkg/koohealth"
+)
+
+func TestDrainDelay(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		delay   time.Duration
+		timeout time.Duration // 0 means no deadline
+		want    time.Duration
+	}{
+		{name: "no deadline", delay: 20 * time.Second, want: 20 * time.Second},
+		{name: "within deadline", delay: 5 * time.Second, timeout: 30 * time.Second, want: 5 * time.Second},
+		{name: "capped by deadline", delay: time.Minute, timeout: 30 * time.Second, want: 15 * time.Second},
+		{name: "disabled", timeout: 30 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+
+			got := drainDelay(ctx, tt.delay)
+
+			// Time passes between setting the deadline and capping the delay
+			if got > tt.want || got < tt.want-time.Second {
+				t.Fatalf("expected drain delay %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestShutdownDrainsWithinDeadline(t *testing.T) {
+	t.Parallel()
+
+	s := &server{
+		config:   &config.Config{App: config.AppConfig{DrainDelay: time.Minute}},
+		logger:   zap.NewNop(),
+		probes:   koohealth.NewProbes(),
+		fiberApp: fiber.New(),
+	}
+
+	ctx, cancel := context.WithTimeout(t.Context(), time.Second)
+	defer cancel()
+
+	start := time.Now()
+
+	if err := s.Shutdown(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !s.probes.Draining() {
+		t.Fatal("expected readiness to fail once shutdown begins")
+	}
+
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond || elapsed > 900*time.Millisecond {
+		t.Fatalf("expected the drain to take half of the deadline, took %s", elapsed)
+	}
+}
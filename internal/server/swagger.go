@@ -0,0 +1,90 @@
+package server
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/swaggo/swag"
+
+	"github.com/kootic/koogo/pkg/koohttp"
+)
+
+// swaggerInstanceName is the swag instance of the served API docs, the generated docs with the error
+// catalog added, see errorCatalogDoc.
+const swaggerInstanceName = "koogo"
+
+var registerSwaggerDoc sync.Once
+
+// SwaggerResponse is a Swagger 2.0 response object.
+type SwaggerResponse struct {
+	Description string         `json:"description"`
+	Schema      SwaggerRef     `json:"schema"`
+	Examples    map[string]any `json:"examples,omitempty"`
+}
+
+// SwaggerRef references a definition of the Swagger document.
+type SwaggerRef struct {
+	Ref string `json:"$ref"`
+}
+
+// ErrorResponses returns the error catalog as Swagger 2.0 responses keyed by error code, with an example
+// of each error. They are added to the top-level responses of the served API docs.
+func ErrorResponses() map[string]SwaggerResponse {
+	responses := make(map[string]SwaggerResponse)
+
+	for _, def := range koohttp.ErrorCatalog() {
+		responses[def.Code] = SwaggerResponse{
+			Description: def.Description,
+			Schema:      SwaggerRef{Ref: "#/definitions/github_com_kootic_koogo_pkg_koohttp.APIResponseError"},
+			Examples: map[string]any{
+				"application/json": koohttp.APIResponseError{
+					Status:    def.Status,
+					ErrorCode: def.Code,
+					Message:   def.Message,
+				},
+			},
+		}
+	}
+
+	return responses
+}
+
+// errorCatalogDoc is the swag document named base with the error catalog added to its responses.
+type errorCatalogDoc struct {
+	base string
+	doc  func() string
+}
+
+func newErrorCatalogDoc(base string) *errorCatalogDoc {
+	d := &errorCatalogDoc{base: base}
+	d.doc = sync.OnceValue(d.build)
+
+	return d
+}
+
+// ReadDoc implements swag.Swagger.
+func (d *errorCatalogDoc) ReadDoc() string {
+	return d.doc()
+}
+
+// build returns the base document unchanged when it cannot be read as JSON, so the docs stay available.
+func (d *errorCatalogDoc) build() string {
+	base, err := swag.ReadDoc(d.base)
+	if err != nil {
+		return ""
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal([]byte(base), &doc); err != nil {
+		return base
+	}
+
+	doc["responses"] = ErrorResponses()
+
+	withErrors, err := json.Marshal(doc)
+	if err != nil {
+		return base
+	}
+
+	return string(withErrors)
+}
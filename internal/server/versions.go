@@ -0,0 +1,129 @@
+package server
+
+import (
+	"fmt"
+)
+
+// apiVersion declares a version of the API. Deprecating a version deprecates every route served under it
+// that does not declare its own deprecation.
+type apiVersion struct {
+	Version     int
+	Deprecation *Deprecation
+}
+
+// apiVersions returns the versions of the API, oldest first. A route is served under the version it is
+// declared in and every later version, until a route of a later version supersedes it.
+func apiVersions() []apiVersion {
+	return []apiVersion{
+		{Version: 1},
+	}
+}
+
+// expandVersions returns the routes served under each API version, ordered by version. Routes are
+// served under their own version and carried over to later versions unless superseded, in which case
+// the successor is served from its version on.
+func expandVersions(routes []route, versions []apiVersion) ([]route, error) {
+	declared := make(map[int]bool)
+
+	for i, version := range versions {
+		if version.Version < 1 || (i > 0 && version.Version <= versions[i-1].Version) {
+			return nil, fmt.Errorf("api version %d: versions must be positive and ascending", version.Version)
+		}
+
+		declared[version.Version] = true
+	}
+
+	byName := make(map[string]route)
+
+	for _, r := range routes {
+		if r.Name == "" {
+			return nil, fmt.Errorf("route %s %s: name must be set", r.Method, r.fullPath())
+		}
+
+		if _, ok := byName[r.Name]; ok {
+			return nil, fmt.Errorf("route %s: name must be unique", r.Name)
+		}
+
+		if !declared[r.Version] {
+			return nil, fmt.Errorf("route %s: unknown api version %d", r.Name, r.Version)
+		}
+
+		byName[r.Name] = r
+	}
+
+	// Successor of each superseded route
+	successors := make(map[string]route)
+
+	for _, r := range routes {
+		if r.Supersedes == "" {
+			continue
+		}
+
+		predecessor, ok := byName[r.Supersedes]
+		if !ok {
+			return nil, fmt.Errorf("route %s: supersedes unknown route %q", r.Name, r.Supersedes)
+		}
+
+		if predecessor.Version >= r.Version {
+			return nil, fmt.Errorf("route %s: supersedes route %q of a later or the same version", r.Name, r.Supersedes)
+		}
+
+		if successor, ok := successors[r.Supersedes]; ok {
+			return nil, fmt.Errorf("route %s: route %q is already superseded by %q", r.Name, r.Supersedes, successor.Name)
+		}
+
+		successors[r.Supersedes] = r
+	}
+
+	var expanded []route
+
+	for _, version := range versions {
+		paths := make(map[string]string)
+
+		for _, r := range routes {
+			successor, superseded := successors[r.Name]
+			if r.Version > version.Version || (superseded && successor.Version <= version.Version) {
+				continue
+			}
+
+			r.introduced = r.Version
+			r.Version = version.Version
+
+			key := r.Method + " " + r.fullPath()
+			if other, ok := paths[key]; ok {
+				return nil, fmt.Errorf("route %s: %s is also served by route %q, declare which one it supersedes", r.Name, key, other)
+			}
+
+			paths[key] = r.Name
+
+			if r.Deprecation == nil {
+				r.Deprecation = version.Deprecation
+			}
+
+			// Point clients of a deprecated route at its successor
+			if r.Deprecation != nil && r.Deprecation.Link == "" && superseded {
+				deprecation := *r.Deprecation
+				deprecation.Link = successor.fullPath()
+				r.Deprecation = &deprecation
+			}
+
+			if superseded {
+				r.supersededBy = successor.Name
+			}
+
+			expanded = append(expanded, r)
+		}
+	}
+
+	return expanded, nil
+}
+
+// versionNumbers returns the numbers of the API versions, oldest first.
+func versionNumbers(versions []apiVersion) []int {
+	numbers := make([]int, len(versions))
+	for i, version := range versions {
+		numbers[i] = version.Version
+	}
+
+	return numbers
+}
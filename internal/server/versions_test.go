@@ -0,0 +1,301 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/kootic/koogo/internal/server/middleware"
+)
+
+func TestExpandVersions(t *testing.T) {
+	t.Parallel()
+
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	listUsers := route{Name: "users.list", Version: 1, Method: http.MethodGet, Path: "/users"}
+	getUser := route{Name: "users.get", Version: 1, Method: http.MethodGet, Path: "/users/:id"}
+	listUsersV2 := route{Name: "users.list.v2", Version: 2, Method: http.MethodGet, Path: "/users", Supersedes: "users.list"}
+
+	tests := []struct {
+		name     string
+		routes   []route
+		versions []apiVersion
+		// want describes each expanded route as "name vVersion introduced=n supersededBy=name link=url"
+		want    []string
+		wantErr string
+	}{
+		{
+			name:     "routes are carried over to later versions",
+			routes:   []route{listUsers, getUser},
+			versions: []apiVersion{{Version: 1}, {Version: 2}},
+			want: []string{
+				"users.list v1 introduced=1 supersededBy= link=",
+				"users.get v1 introduced=1 supersededBy= link=",
+				"users.list v2 introduced=1 supersededBy= link=",
+				"users.get v2 introduced=1 supersededBy= link=",
+			},
+		},
+		{
+			name:     "superseded routes are replaced from the successor's version on",
+			routes:   []route{listUsers, getUser, listUsersV2},
+			versions: []apiVersion{{Version: 1}, {Version: 2}, {Version: 3}},
+			want: []string{
+				"users.list v1 introduced=1 supersededBy=users.list.v2 link=",
+				"users.get v1 introduced=1 supersededBy= link=",
+				"users.get v2 introduced=1 supersededBy= link=",
+				"users.list.v2 v2 introduced=2 supersededBy= link=",
+				"users.get v3 introduced=1 supersededBy= link=",
+				"users.list.v2 v3 introduced=2 supersededBy= link=",
+			},
+		},
+		{
+			name:   "deprecated versions link superseded routes to their successor",
+			routes: []route{listUsers, getUser, listUsersV2},
+			versions: []apiVersion{
+				{Version: 1, Deprecation: &Deprecation{Since: since}},
+				{Version: 2},
+			},
+			want: []string{
+				"users.list v1 introduced=1 supersededBy=users.list.v2 link=/api/v2/users",
+				"users.get v1 introduced=1 supersededBy= link=",
+				"users.get v2 introduced=1 supersededBy= link=",
+				"users.list.v2 v2 introduced=2 supersededBy= link=",
+			},
+		},
+		{
+			name: "own deprecation link is kept",
+			routes: []route{
+				{
+					Name: "users.list", Version: 1, Method: http.MethodGet, Path: "/users",
+					Deprecation: &Deprecation{Since: since, Link: "https://example.com/migrate"},
+				},
+				listUsersV2,
+			},
+			versions: []apiVersion{{Version: 1}, {Version: 2}},
+			want: []string{
+				"users.list v1 introduced=1 supersededBy=users.list.v2 link=https://example.com/migrate",
+				"users.list.v2 v2 introduced=2 supersededBy= link=",
+			},
+		},
+		{
+			name: "duplicate paths",
+			routes: []route{
+				listUsers,
+				{Name: "users.search", Version: 2, Method: http.MethodGet, Path: "/users"},
+			},
+			versions: []apiVersion{{Version: 1}, {Version: 2}},
+			wantErr:  `GET /api/v2/users is also served by route "users.list"`,
+		},
+		{
+			name: "unknown supersedes",
+			routes: []route{
+				listUsers,
+				{Name: "users.list.v2", Version: 2, Method: http.MethodGet, Path: "/users", Supersedes: "users.all"},
+			},
+			versions: []apiVersion{{Version: 1}, {Version: 2}},
+			wantErr:  `supersedes unknown route "users.all"`,
+		},
+		{
+			name:     "unknown version",
+			routes:   []route{listUsersV2},
+			versions: []apiVersion{{Version: 1}},
+			wantErr:  "unknown api version 2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			expanded, err := expandVersions(tt.routes, tt.versions)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got := make([]string, len(expanded))
+			names := make(map[string]bool)
+
+			for i, r := range expanded {
+				if names[r.versionedName()] {
+					t.Fatalf("expected unique versioned names, got %s twice", r.versionedName())
+				}
+
+				names[r.versionedName()] = true
+
+				link := ""
+				if r.Deprecation != nil {
+					link = r.Deprecation.Link
+				}
+
+				got[i] = fmt.Sprintf("%s v%d introduced=%d supersededBy=%s link=%s",
+					r.Name, r.Version, r.introduced, r.supersededBy, link)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("expected routes\n%s\ngot\n%s", strings.Join(tt.want, "\n"), strings.Join(got, "\n"))
+			}
+		})
+	}
+}
+
+func TestAPIVersionRouting(t *testing.T) {
+	t.Parallel()
+
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	sunset := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	versions := []apiVersion{
+		{Version: 1, Deprecation: &Deprecation{Since: since, Sunset: sunset, Link: "https://example.com/v2"}},
+		{Version: 2},
+	}
+
+	servedBy := func(name string) fiber.Handler {
+		return func(c *fiber.Ctx) error { return c.SendString(name) }
+	}
+
+	group := routeGroup{Routes: []route{
+		{Name: "users.list", Version: 1, Method: http.MethodGet, Path: "/users", Handler: servedBy("users.list")},
+		{Name: "users.get", Version: 2, Method: http.MethodGet, Path: "/users/:id", Handler: servedBy("users.get")},
+	}}
+
+	routes, err := expandVersions(group.resolve(), versions)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	s := &server{authPolicies: authPolicies()}
+
+	app := fiber.New()
+	app.Use(middleware.APIVersion(APIBasePath, versionNumbers(versions)))
+
+	for _, r := range routes {
+		handlers, err := s.handlers(r)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		app.Add(r.Method, r.fullPath(), handlers...)
+	}
+
+	deprecated := map[string]string{
+		"Deprecation": "@1767225600",
+		"Sunset":      "Wed, 01 Jul 2026 00:00:00 GMT",
+		"Link":        `<https://example.com/v2>; rel="deprecation"`,
+	}
+	current := map[string]string{"Deprecation": "", "Sunset": "", "Link": ""}
+
+	tests := []struct {
+		name          string
+		path          string
+		acceptVersion string
+		wantStatus    int
+		wantVersion   string
+		wantBody      string
+		wantHeaders   map[string]string
+	}{
+		{
+			name:        "unversioned path resolves to the latest version",
+			path:        "/api/users",
+			wantStatus:  http.StatusOK,
+			wantVersion: "2",
+			wantBody:    "users.list",
+			wantHeaders: current,
+		},
+		{
+			name:          "accept version selects an earlier version",
+			path:          "/api/users",
+			acceptVersion: "1",
+			wantStatus:    http.StatusOK,
+			wantVersion:   "1",
+			wantBody:      "users.list",
+			wantHeaders:   deprecated,
+		},
+		{
+			name:          "accept version with a v prefix",
+			path:          "/api/users/1",
+			acceptVersion: "v2",
+			wantStatus:    http.StatusOK,
+			wantVersion:   "2",
+			wantBody:      "users.get",
+			wantHeaders:   current,
+		},
+		{
+			name:        "versioned path of a deprecated version",
+			path:        "/api/v1/users",
+			wantStatus:  http.StatusOK,
+			wantVersion: "1",
+			wantBody:    "users.list",
+			wantHeaders: deprecated,
+		},
+		{
+			name:          "unknown version",
+			path:          "/api/users",
+			acceptVersion: "3",
+			wantStatus:    http.StatusBadRequest,
+		},
+		{
+			name:          "malformed version",
+			path:          "/api/users",
+			acceptVersion: "latest",
+			wantStatus:    http.StatusBadRequest,
+		},
+		{
+			name:          "route of a later version",
+			path:          "/api/users/1",
+			acceptVersion: "1",
+			wantStatus:    http.StatusNotFound,
+			wantVersion:   "1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.acceptVersion != "" {
+				req.Header.Set(middleware.HeaderAcceptVersion, tt.acceptVersion)
+			}
+
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, resp.StatusCode)
+			}
+
+			if version := resp.Header.Get(middleware.HeaderAPIVersion); version != tt.wantVersion {
+				t.Fatalf("expected API version %q, got %q", tt.wantVersion, version)
+			}
+
+			if tt.wantBody != "" {
+				if body, _ := io.ReadAll(resp.Body); string(body) != tt.wantBody {
+					t.Fatalf("expected the request to be served by %s, got %s", tt.wantBody, body)
+				}
+			}
+
+			for header, want := range tt.wantHeaders {
+				if got := resp.Header.Get(header); got != want {
+					t.Fatalf("expected %s header %q, got %q", header, want, got)
+				}
+			}
+		})
+	}
+}
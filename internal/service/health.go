@@ -3,21 +3,21 @@ package service
 import (
 	"context"
 
-	"github.com/kootic/koogo/internal/repo"
+	"github.com/kootic/koogo/pkg/koohealth"
 )
 
 type HealthService interface {
-	HealthCheck(ctx context.Context) error
+	HealthCheck(ctx context.Context) koohealth.Report
 }
 
 type healthService struct {
-	healthRepo repo.HealthRepository
+	checks *koohealth.Registry
 }
 
-func NewHealthService(healthRepo repo.HealthRepository) HealthService {
-	return &healthService{healthRepo: healthRepo}
+func NewHealthService(checks *koohealth.Registry) HealthService {
+	return &healthService{checks: checks}
 }
 
-func (s *healthService) HealthCheck(ctx context.Context) error {
-	return s.healthRepo.Ping(ctx)
+func (s *healthService) HealthCheck(ctx context.Context) koohealth.Report {
+	return s.checks.Run(ctx)
 }
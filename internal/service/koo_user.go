@@ -17,8 +17,18 @@ import (
 )
 
 var (
-	ErrUserNotFound        = koohttp.NewAPIError(http.StatusNotFound, "user_not_found")
-	ErrUserIsNotSubscribed = koohttp.NewAPIError(http.StatusForbidden, "user_is_not_subscribed")
+	ErrUserNotFound = koohttp.DefineError(koohttp.ErrorDefinition{
+		Code:        "user_not_found",
+		Status:      http.StatusNotFound,
+		Description: "The user does not exist.",
+		Message:     "The user was not found",
+	})
+	ErrUserIsNotSubscribed = koohttp.DefineError(koohttp.ErrorDefinition{
+		Code:        "user_is_not_subscribed",
+		Status:      http.StatusForbidden,
+		Description: "The feature requires the user to have a subscription.",
+		Message:     "The user is not subscribed",
+	})
 )
 
 type KooUserService interface {
@@ -1,15 +1,18 @@
 package service
 
-import "github.com/kootic/koogo/internal/repo"
+import (
+	"github.com/kootic/koogo/internal/repo"
+	"github.com/kootic/koogo/pkg/koohealth"
+)
 
 type Services struct {
 	HealthService  HealthService
 	KooUserService KooUserService
 }
 
-func NewServices(repos *repo.Repositories) *Services {
+func NewServices(repos *repo.Repositories, checks *koohealth.Registry) *Services {
 	return &Services{
-		HealthService:  NewHealthService(repos.Health),
+		HealthService:  NewHealthService(checks),
 		KooUserService: NewKooUserService(repos.User, repos.Pet),
 	}
 }
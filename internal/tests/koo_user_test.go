@@ -2,6 +2,7 @@ package tests
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"testing"
 
@@ -9,6 +10,7 @@ import (
 
 	"github.com/kootic/koogo/internal/dto"
 	"github.com/kootic/koogo/internal/tests/testutils"
+	"github.com/kootic/koogo/pkg/koohttp"
 )
 
 func TestKooUser(t *testing.T) {
@@ -71,3 +73,55 @@ func TestKooUser(t *testing.T) {
 
 	testutils.RunTestPlan(t, plan)
 }
+
+func TestKooUserValidation(t *testing.T) {
+	t.Parallel()
+
+	expectFieldError := func(field, code string) func(*testing.T, testutils.TestResponse, map[string]any) error {
+		return func(t *testing.T, response testutils.TestResponse, globalVars map[string]any) error {
+			apiErr, err := testutils.DecodeTestResponse[koohttp.APIResponseError](response)
+			if err != nil {
+				return err
+			}
+
+			if apiErr.ErrorCode != koohttp.APIErrorCodeValidationFailed {
+				return fmt.Errorf("expected error code %s, got %s", koohttp.APIErrorCodeValidationFailed, apiErr.ErrorCode)
+			}
+
+			if len(apiErr.Errors) != 1 || apiErr.Errors[0].Field != field || apiErr.Errors[0].Code != code {
+				return fmt.Errorf("expected a %s error for %s, got %+v", code, field, apiErr.Errors)
+			}
+
+			return nil
+		}
+	}
+
+	plan := testutils.TestPlan{
+		func(globalVars map[string]any) testutils.TestStep {
+			return testutils.TestStep{
+				Name:             "missing first name",
+				Path:             "/api/v1/koo/users",
+				Method:           http.MethodPost,
+				ContentType:      "application/json",
+				Body:             map[string]any{},
+				ExpectStatusCode: http.StatusUnprocessableEntity,
+				ValidateResponse: expectFieldError("firstName", koohttp.FieldErrorCodeRequired),
+			}
+		},
+		func(globalVars map[string]any) testutils.TestStep {
+			return testutils.TestStep{
+				Name:        "first name of the wrong type",
+				Path:        "/api/v1/koo/users",
+				Method:      http.MethodPost,
+				ContentType: "application/json",
+				Body: map[string]any{
+					"firstName": 1,
+				},
+				ExpectStatusCode: http.StatusUnprocessableEntity,
+				ValidateResponse: expectFieldError("firstName", koohttp.FieldErrorCodeType),
+			}
+		},
+	}
+
+	testutils.RunTestPlan(t, plan)
+}
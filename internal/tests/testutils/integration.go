@@ -18,6 +18,7 @@ import (
 	"github.com/kootic/koogo/internal/app"
 	"github.com/kootic/koogo/internal/config"
 	"github.com/kootic/koogo/pkg/koodb"
+	"github.com/kootic/koogo/pkg/koohttp"
 )
 
 const (
@@ -30,11 +31,18 @@ var (
 	TestApp    *app.App
 	TestConfig = &config.Config{
 		App: config.AppConfig{
-			Name:     "test",
-			Version:  "test",
-			Env:      config.AppEnvTest,
-			Port:     8080,
-			LogLevel: config.AppLogLevelDebug,
+			Name:        "test",
+			Version:     "test",
+			Env:         config.AppEnvTest,
+			Port:        8080,
+			ErrorFormat: koohttp.ErrorFormatLegacy,
+		},
+		Runtime: config.RuntimeConfig{
+			LogLevel:         config.AppLogLevelDebug,
+			LogRequests:      true,
+			LogRequestBodies: true,
+			RequestLog:       config.RequestLogConfig{SuccessSampleRate: 1},
+			FeatureFlags:     config.FeatureFlagsConfig{Store: config.FeatureFlagStoreConfig},
 		},
 		Database: config.DatabaseConfig{
 			Host:     "localhost",
@@ -2,6 +2,8 @@ package kooctx
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"go.uber.org/zap"
 )
@@ -9,7 +11,12 @@ import (
 type contextKey string
 
 const (
-	ContextKeyLogger contextKey = "logger"
+	ContextKeyLogger        contextKey = "logger"
+	ContextKeyAttributes    contextKey = "attributes"
+	ContextKeyFlagEvaluator contextKey = "flagEvaluator"
+	ContextKeyRequestID     contextKey = "requestID"
+	ContextKeyEvent         contextKey = "event"
+	ContextKeyTranslator    contextKey = "translator"
 )
 
 func getValueFromContext[T any](ctx context.Context, key contextKey) (T, bool) {
@@ -36,3 +43,124 @@ func WithLoggerFields(ctx context.Context, fields ...zap.Field) (context.Context
 
 	return SetContextLogger(ctx, newLogger), newLogger
 }
+
+func SetContextRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, ContextKeyRequestID, requestID)
+}
+
+// GetContextRequestID returns the ID of the current request, or "" outside of a request.
+func GetContextRequestID(ctx context.Context) string {
+	requestID, _ := getValueFromContext[string](ctx, ContextKeyRequestID)
+	return requestID
+}
+
+// Event accumulates the fields of the canonical log line emitted once per request, so handlers and
+// services can describe what a request did without logging separately. It is safe for concurrent use.
+type Event struct {
+	mu         sync.Mutex
+	fields     []zap.Field
+	dbQueries  int
+	dbDuration time.Duration
+}
+
+// Fields returns the fields added to the event, followed by the database query count and time.
+func (e *Event) Fields() []zap.Field {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return append(append([]zap.Field{}, e.fields...),
+		zap.Int("db_queries", e.dbQueries),
+		zap.Float64("db_time_ms", float64(e.dbDuration.Microseconds())/1000.0),
+	)
+}
+
+func SetContextEvent(ctx context.Context, event *Event) context.Context {
+	return context.WithValue(ctx, ContextKeyEvent, event)
+}
+
+func GetContextEvent(ctx context.Context) (*Event, bool) {
+	return getValueFromContext[*Event](ctx, ContextKeyEvent)
+}
+
+// AddField adds a field to the canonical log line of the current request, e.g.
+// kooctx.AddField(ctx, "user_id", id). It does nothing outside of a request.
+func AddField(ctx context.Context, key string, value any) {
+	event, ok := GetContextEvent(ctx)
+	if !ok {
+		return
+	}
+
+	event.mu.Lock()
+	defer event.mu.Unlock()
+
+	event.fields = append(event.fields, zap.Any(key, value))
+}
+
+// RecordQuery counts a database query and its duration on the canonical log line of the current request.
+// It does nothing outside of a request.
+func RecordQuery(ctx context.Context, duration time.Duration) {
+	event, ok := GetContextEvent(ctx)
+	if !ok {
+		return
+	}
+
+	event.mu.Lock()
+	defer event.mu.Unlock()
+
+	event.dbQueries++
+	event.dbDuration += duration
+}
+
+// Attributes describe the current request for feature flag evaluation, see kooflag.
+type Attributes struct {
+	UserID      string
+	Environment string
+	// Headers holds request headers keyed by their canonical name, e.g. "X-Beta".
+	Headers map[string]string
+}
+
+// FlagEvaluator evaluates feature flags, it is implemented by kooflag.Evaluator.
+type FlagEvaluator interface {
+	IsEnabled(ctx context.Context, key string) bool
+}
+
+func SetContextAttributes(ctx context.Context, attrs Attributes) context.Context {
+	return context.WithValue(ctx, ContextKeyAttributes, attrs)
+}
+
+func GetContextAttributes(ctx context.Context) Attributes {
+	attrs, _ := getValueFromContext[Attributes](ctx, ContextKeyAttributes)
+	return attrs
+}
+
+// SetContextUserID sets the user ID used for feature flag rules and percentage rollouts,
+// e.g. once the request has been authenticated.
+func SetContextUserID(ctx context.Context, userID string) context.Context {
+	attrs := GetContextAttributes(ctx)
+	attrs.UserID = userID
+
+	return SetContextAttributes(ctx, attrs)
+}
+
+func SetContextFlagEvaluator(ctx context.Context, evaluator FlagEvaluator) context.Context {
+	return context.WithValue(ctx, ContextKeyFlagEvaluator, evaluator)
+}
+
+func GetContextFlagEvaluator(ctx context.Context) (FlagEvaluator, bool) {
+	return getValueFromContext[FlagEvaluator](ctx, ContextKeyFlagEvaluator)
+}
+
+// Translator translates messages into the locale of the current request, it is implemented by
+// kooi18n.Localizer.
+type Translator interface {
+	Locale() string
+	Translate(key string) (string, bool)
+}
+
+func SetContextTranslator(ctx context.Context, translator Translator) context.Context {
+	return context.WithValue(ctx, ContextKeyTranslator, translator)
+}
+
+func GetContextTranslator(ctx context.Context) (Translator, bool) {
+	return getValueFromContext[Translator](ctx, ContextKeyTranslator)
+}
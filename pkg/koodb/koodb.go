@@ -5,6 +5,8 @@ import (
 	"database/sql"
 	"fmt"
 	"math"
+	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/DATA-DOG/go-txdb"
@@ -22,6 +24,40 @@ type PoolConfig struct {
 	ConnectionTimeout time.Duration // Timeout for establishing a connection (default: 10 seconds)
 }
 
+// ConnParams holds optional PostgreSQL connection parameters that are encoded into the DSN,
+// so they apply to every connection regardless of how the sql.DB handle is created.
+type ConnParams struct {
+	SSLMode            string        // sslmode, e.g. disable, require or verify-full
+	SSLRootCert        string        // sslrootcert, path to the CA certificate used to verify the server
+	ApplicationName    string        // application_name reported in pg_stat_activity
+	SearchPath         string        // search_path, comma separated list of schemas
+	StatementTimeout   time.Duration // statement_timeout, zero keeps the server default
+	TargetSessionAttrs string        // target_session_attrs, e.g. read-write or primary
+}
+
+// Apply sets every non-empty parameter on the query string of u, overriding existing values.
+func (p ConnParams) Apply(u *url.URL) {
+	query := u.Query()
+
+	set := func(key, value string) {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+
+	set("sslmode", p.SSLMode)
+	set("sslrootcert", p.SSLRootCert)
+	set("application_name", p.ApplicationName)
+	set("search_path", p.SearchPath)
+	set("target_session_attrs", p.TargetSessionAttrs)
+
+	if p.StatementTimeout > 0 {
+		query.Set("statement_timeout", strconv.FormatInt(p.StatementTimeout.Milliseconds(), 10))
+	}
+
+	u.RawQuery = query.Encode()
+}
+
 // NewPostgresPool creates a new sql.DB handle with custom pool configuration.
 func NewPostgresPool(ctx context.Context, dsn string, poolConfig *PoolConfig) (*sql.DB, error) {
 	pgxPoolConfig, err := pgxpool.ParseConfig(dsn)
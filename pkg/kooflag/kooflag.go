@@ -0,0 +1,256 @@
+// Package kooflag evaluates feature flags against the attributes of the current request.
+package kooflag
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+
+	"github.com/kootic/koogo/pkg/kooctx"
+)
+
+// Attribute names that rules can match on. Headers are matched with AttributeHeaderPrefix
+// followed by the header name, e.g. "header:X-Beta".
+const (
+	AttributeUserID       = "userId"
+	AttributeEnvironment  = "env"
+	AttributeHeaderPrefix = "header:"
+)
+
+// Flag is a feature flag definition.
+type Flag struct {
+	Key string `json:"key" yaml:"key"`
+	// Enabled is the kill switch, a disabled flag is off for everyone regardless of its rules.
+	Enabled bool `json:"enabled" yaml:"enabled"`
+	// Rules are evaluated in order and the first matching rule decides the result.
+	Rules []Rule `json:"rules,omitempty" yaml:"rules,omitempty"`
+	// Percentage, when set, enables the flag for a stable 0-100 percentage of users
+	// that did not match any rule. Requests without a user ID are excluded from the rollout.
+	Percentage *int `json:"percentage,omitempty" yaml:"percentage,omitempty"`
+}
+
+// Rule enables or disables a flag when a request attribute matches any of Values.
+type Rule struct {
+	Attribute string   `json:"attribute" yaml:"attribute"`
+	Values    []string `json:"values"    yaml:"values"`
+	Enabled   bool     `json:"enabled"   yaml:"enabled"`
+}
+
+// Store provides flag definitions.
+type Store interface {
+	// Flag returns the flag with key, ok is false when the flag is not defined.
+	Flag(ctx context.Context, key string) (flag Flag, ok bool, err error)
+}
+
+// Evaluate returns whether flag is enabled for attrs.
+func (f Flag) Evaluate(attrs kooctx.Attributes) bool {
+	if !f.Enabled {
+		return false
+	}
+
+	for _, rule := range f.Rules {
+		if rule.matches(attrs) {
+			return rule.Enabled
+		}
+	}
+
+	if f.Percentage == nil {
+		return true
+	}
+
+	if attrs.UserID == "" {
+		return false
+	}
+
+	return bucket(f.Key, attrs.UserID) < *f.Percentage
+}
+
+func (r Rule) matches(attrs kooctx.Attributes) bool {
+	var value string
+
+	switch {
+	case r.Attribute == AttributeUserID:
+		value = attrs.UserID
+	case r.Attribute == AttributeEnvironment:
+		value = attrs.Environment
+	case strings.HasPrefix(r.Attribute, AttributeHeaderPrefix):
+		value = attrs.Headers[http.CanonicalHeaderKey(strings.TrimPrefix(r.Attribute, AttributeHeaderPrefix))]
+	}
+
+	if value == "" {
+		return false
+	}
+
+	for _, candidate := range r.Values {
+		if candidate == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// bucket deterministically assigns a user to a bucket between 0 and 99 for a flag,
+// so the same user keeps the same result and rollouts of different flags are independent.
+func bucket(key, userID string) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key + ":" + userID))
+
+	return int(h.Sum32() % 100)
+}
+
+// Evaluator evaluates flags from a Store that can be replaced at runtime.
+type Evaluator struct {
+	store atomic.Pointer[Store]
+}
+
+var _ kooctx.FlagEvaluator = (*Evaluator)(nil)
+
+// NewEvaluator creates an Evaluator backed by store.
+func NewEvaluator(store Store) *Evaluator {
+	e := &Evaluator{}
+	e.SetStore(store)
+
+	return e
+}
+
+// SetStore replaces the store used for subsequent evaluations, e.g. when the config is reloaded.
+func (e *Evaluator) SetStore(store Store) {
+	e.store.Store(&store)
+}
+
+// IsEnabled evaluates the flag with key against the attributes in ctx. Undefined flags
+// and store errors evaluate to false, errors are logged with the context logger.
+func (e *Evaluator) IsEnabled(ctx context.Context, key string) bool {
+	flag, ok, err := (*e.store.Load()).Flag(ctx, key)
+	if err != nil {
+		kooctx.GetContextLogger(ctx).Error("Failed to get feature flag", zap.String("flag", key), zap.Error(err))
+		return false
+	}
+
+	if !ok {
+		return false
+	}
+
+	return flag.Evaluate(kooctx.GetContextAttributes(ctx))
+}
+
+// IsEnabled evaluates the flag with key using the evaluator and attributes stored in ctx,
+// see kooctx.SetContextFlagEvaluator. It returns false when no evaluator is available.
+func IsEnabled(ctx context.Context, key string) bool {
+	evaluator, ok := kooctx.GetContextFlagEvaluator(ctx)
+	if !ok {
+		return false
+	}
+
+	return evaluator.IsEnabled(ctx, key)
+}
+
+type staticStore struct {
+	flags map[string]Flag
+}
+
+// NewStaticStore returns a Store serving a fixed set of flags, e.g. loaded from the environment or a file.
+func NewStaticStore(flags []Flag) Store {
+	s := &staticStore{flags: make(map[string]Flag, len(flags))}
+	for _, flag := range flags {
+		s.flags[flag.Key] = flag
+	}
+
+	return s
+}
+
+func (s *staticStore) Flag(ctx context.Context, key string) (Flag, bool, error) {
+	flag, ok := s.flags[key]
+	return flag, ok, nil
+}
+
+// LoadFile reads flag definitions from a YAML (.yaml, .yml) or JSON (.json) file containing a list of flags.
+func LoadFile(path string) ([]Flag, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var flags []Flag
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &flags)
+	case ".json":
+		err = json.Unmarshal(data, &flags)
+	default:
+		return nil, fmt.Errorf("unsupported feature flag file extension %q, expected .yaml, .yml or .json", ext)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse feature flag file: %w", err)
+	}
+
+	for _, flag := range flags {
+		if flag.Key == "" {
+			return nil, fmt.Errorf("feature flag without key in %s", path)
+		}
+
+		if flag.Percentage != nil && (*flag.Percentage < 0 || *flag.Percentage > 100) {
+			return nil, fmt.Errorf("feature flag %s: percentage must be 0-100", flag.Key)
+		}
+	}
+
+	return flags, nil
+}
+
+type cachedFlag struct {
+	flag      Flag
+	ok        bool
+	expiresAt time.Time
+}
+
+type cachedStore struct {
+	store Store
+	ttl   time.Duration
+	mu    sync.Mutex
+	cache map[string]cachedFlag
+}
+
+// NewCachedStore wraps store and caches each flag, including undefined flags, for ttl.
+// It is intended for stores backed by a database that would otherwise be queried on every evaluation.
+func NewCachedStore(store Store, ttl time.Duration) Store {
+	return &cachedStore{
+		store: store,
+		ttl:   ttl,
+		cache: make(map[string]cachedFlag),
+	}
+}
+
+func (s *cachedStore) Flag(ctx context.Context, key string) (Flag, bool, error) {
+	s.mu.Lock()
+	cached, found := s.cache[key]
+	s.mu.Unlock()
+
+	if found && time.Now().Before(cached.expiresAt) {
+		return cached.flag, cached.ok, nil
+	}
+
+	flag, ok, err := s.store.Flag(ctx, key)
+	if err != nil {
+		return Flag{}, false, err
+	}
+
+	s.mu.Lock()
+	s.cache[key] = cachedFlag{flag: flag, ok: ok, expiresAt: time.Now().Add(s.ttl)}
+	s.mu.Unlock()
+
+	return flag, ok, nil
+}
@@ -0,0 +1,100 @@
+package kooflag_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/kootic/koogo/pkg/kooctx"
+	"github.com/kootic/koogo/pkg/kooflag"
+)
+
+func TestFlagEvaluate(t *testing.T) {
+	t.Parallel()
+
+	half := 50
+
+	flag := kooflag.Flag{
+		Key:     "new_checkout",
+		Enabled: true,
+		Rules: []kooflag.Rule{
+			{Attribute: kooflag.AttributeHeaderPrefix + "x-beta", Values: []string{"1"}, Enabled: true},
+			{Attribute: kooflag.AttributeEnvironment, Values: []string{"prod"}, Enabled: false},
+		},
+		Percentage: &half,
+	}
+
+	tests := []struct {
+		name  string
+		flag  kooflag.Flag
+		attrs kooctx.Attributes
+		want  bool
+	}{
+		{name: "disabled", flag: kooflag.Flag{Key: "off"}, attrs: kooctx.Attributes{}, want: false},
+		{name: "enabled without rollout", flag: kooflag.Flag{Key: "on", Enabled: true}, attrs: kooctx.Attributes{}, want: true},
+		{
+			name:  "header rule",
+			flag:  flag,
+			attrs: kooctx.Attributes{Environment: "prod", Headers: map[string]string{"X-Beta": "1"}},
+			want:  true,
+		},
+		{name: "environment rule", flag: flag, attrs: kooctx.Attributes{Environment: "prod", UserID: "user"}, want: false},
+		{name: "rollout without user", flag: flag, attrs: kooctx.Attributes{Environment: "dev"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.flag.Evaluate(tt.attrs); got != tt.want {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestFlagEvaluatePercentage(t *testing.T) {
+	t.Parallel()
+
+	percentage := 25
+	flag := kooflag.Flag{Key: "rollout", Enabled: true, Percentage: &percentage}
+
+	enabled := 0
+
+	for i := range 1000 {
+		attrs := kooctx.Attributes{UserID: fmt.Sprintf("user-%d", i)}
+		if flag.Evaluate(attrs) {
+			enabled++
+		}
+
+		if flag.Evaluate(attrs) != flag.Evaluate(attrs) {
+			t.Fatalf("expected stable result for %s", attrs.UserID)
+		}
+	}
+
+	if enabled < 200 || enabled > 300 {
+		t.Fatalf("expected roughly 25%% of users to be enabled, got %d of 1000", enabled)
+	}
+}
+
+func TestIsEnabled(t *testing.T) {
+	t.Parallel()
+
+	evaluator := kooflag.NewEvaluator(kooflag.NewStaticStore([]kooflag.Flag{
+		{Key: "beta", Enabled: true, Rules: []kooflag.Rule{{Attribute: kooflag.AttributeUserID, Values: []string{"42"}, Enabled: true}}, Percentage: new(int)},
+	}))
+
+	ctx := context.Background()
+	if kooflag.IsEnabled(ctx, "beta") {
+		t.Fatal("expected false without an evaluator in the context")
+	}
+
+	ctx = kooctx.SetContextFlagEvaluator(ctx, evaluator)
+	if kooflag.IsEnabled(ctx, "beta") || kooflag.IsEnabled(ctx, "undefined") {
+		t.Fatal("expected false for users outside the rule and undefined flags")
+	}
+
+	if !kooflag.IsEnabled(kooctx.SetContextUserID(ctx, "42"), "beta") {
+		t.Fatal("expected true for the user matching the rule")
+	}
+}
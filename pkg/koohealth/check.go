@@ -0,0 +1,166 @@
+package koohealth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultCheckTimeout bounds checks registered without a timeout.
+const DefaultCheckTimeout = 5 * time.Second
+
+// Status is the health of a single check or of the whole registry.
+type Status string
+
+const (
+	StatusUp Status = "up"
+	// StatusDegraded means a non-critical check failed, the application still serves traffic.
+	StatusDegraded Status = "degraded"
+	StatusDown     Status = "down"
+)
+
+// Check is a named health check registered by a component, e.g. the database or an outbound dependency.
+type Check struct {
+	Name string
+	// Critical checks fail the report when they fail, others only degrade it.
+	Critical bool
+	Timeout  time.Duration
+	Check    func(ctx context.Context) error
+}
+
+// CheckResult is the outcome of the latest run of a check.
+type CheckResult struct {
+	Name      string    `json:"name"`
+	Status    Status    `json:"status"`
+	Critical  bool      `json:"critical"`
+	LatencyMS int64     `json:"latencyMs"`
+	CheckedAt time.Time `json:"checkedAt"`
+	// LastError is the most recent failure, which is kept after the check recovers.
+	LastError   string     `json:"lastError,omitempty"`
+	LastErrorAt *time.Time `json:"lastErrorAt,omitempty"`
+}
+
+// Report is the result of running every registered check.
+type Report struct {
+	Status Status        `json:"status"`
+	Checks []CheckResult `json:"checks"`
+}
+
+// Registry runs registered checks concurrently and caches their results. It is safe for concurrent use.
+type Registry struct {
+	cacheTTL time.Duration
+	mu       sync.Mutex
+	checks   []Check
+	results  map[string]CheckResult
+}
+
+// NewRegistry creates an empty Registry that reuses check results for cacheTTL, so frequent probes
+// do not overload the dependencies they check.
+func NewRegistry(cacheTTL time.Duration) *Registry {
+	return &Registry{
+		cacheTTL: cacheTTL,
+		results:  make(map[string]CheckResult),
+	}
+}
+
+// Register adds a check. Check names must be unique.
+func (r *Registry) Register(check Check) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if check.Name == "" || check.Check == nil {
+		return errors.New("health check name and function are required")
+	}
+
+	for _, c := range r.checks {
+		if c.Name == check.Name {
+			return fmt.Errorf("health check %s is already registered", check.Name)
+		}
+	}
+
+	if check.Timeout <= 0 {
+		check.Timeout = DefaultCheckTimeout
+	}
+
+	r.checks = append(r.checks, check)
+
+	return nil
+}
+
+// Run runs every check whose cached result has expired and returns the report. The report is down when
+// a critical check fails and degraded when only non-critical checks fail.
+func (r *Registry) Run(ctx context.Context) Report {
+	r.mu.Lock()
+	checks := make([]Check, len(r.checks))
+	copy(checks, r.checks)
+	r.mu.Unlock()
+
+	report := Report{Status: StatusUp, Checks: make([]CheckResult, len(checks))}
+
+	var wg sync.WaitGroup
+
+	for i, check := range checks {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			report.Checks[i] = r.result(ctx, check)
+		}()
+	}
+
+	wg.Wait()
+
+	for _, result := range report.Checks {
+		switch {
+		case result.Status == StatusUp:
+		case result.Critical:
+			report.Status = StatusDown
+		case report.Status == StatusUp:
+			report.Status = StatusDegraded
+		}
+	}
+
+	return report
+}
+
+// result returns the cached result of check, running it when the cache has expired.
+func (r *Registry) result(ctx context.Context, check Check) CheckResult {
+	r.mu.Lock()
+	cached, ok := r.results[check.Name]
+	r.mu.Unlock()
+
+	if ok && time.Since(cached.CheckedAt) < r.cacheTTL {
+		return cached
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, check.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check.Check(ctx)
+
+	result := CheckResult{
+		Name:        check.Name,
+		Status:      StatusUp,
+		Critical:    check.Critical,
+		LatencyMS:   time.Since(start).Milliseconds(),
+		CheckedAt:   start,
+		LastError:   cached.LastError,
+		LastErrorAt: cached.LastErrorAt,
+	}
+
+	if err != nil {
+		result.Status = StatusDown
+		result.LastError = err.Error()
+		result.LastErrorAt = &start
+	}
+
+	r.mu.Lock()
+	r.results[check.Name] = result
+	r.mu.Unlock()
+
+	return result
+}
@@ -0,0 +1,88 @@
+package koohealth_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kootic/koogo/pkg/koohealth"
+)
+
+func TestRegistryRun(t *testing.T) {
+	t.Parallel()
+
+	errUnavailable := errors.New("unavailable")
+
+	tests := []struct {
+		name     string
+		critical error
+		optional error
+		want     koohealth.Status
+	}{
+		{name: "up", want: koohealth.StatusUp},
+		{name: "non-critical failure degrades", optional: errUnavailable, want: koohealth.StatusDegraded},
+		{name: "critical failure is down", critical: errUnavailable, optional: errUnavailable, want: koohealth.StatusDown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			registry := koohealth.NewRegistry(0)
+			_ = registry.Register(koohealth.Check{
+				Name:     "database",
+				Critical: true,
+				Check:    func(ctx context.Context) error { return tt.critical },
+			})
+			_ = registry.Register(koohealth.Check{
+				Name:  "cache",
+				Check: func(ctx context.Context) error { return tt.optional },
+			})
+
+			report := registry.Run(t.Context())
+			if report.Status != tt.want {
+				t.Fatalf("expected %s, got %s", tt.want, report.Status)
+			}
+
+			if len(report.Checks) != 2 || report.Checks[0].Name != "database" || report.Checks[1].Name != "cache" {
+				t.Fatalf("expected results in registration order, got %+v", report.Checks)
+			}
+		})
+	}
+}
+
+func TestRegistryCacheAndTimeout(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	registry := koohealth.NewRegistry(time.Minute)
+	_ = registry.Register(koohealth.Check{
+		Name:     "slow",
+		Critical: true,
+		Timeout:  10 * time.Millisecond,
+		Check: func(ctx context.Context) error {
+			calls.Add(1)
+			<-ctx.Done()
+
+			return ctx.Err()
+		},
+	})
+
+	first := registry.Run(t.Context())
+	second := registry.Run(t.Context())
+
+	if calls.Load() != 1 {
+		t.Fatalf("expected cached result, check ran %d times", calls.Load())
+	}
+
+	if first.Status != koohealth.StatusDown || second.Checks[0].LastError != context.DeadlineExceeded.Error() {
+		t.Fatalf("expected timed out check to be down, got %+v", second)
+	}
+
+	if err := registry.Register(koohealth.Check{Name: "slow", Check: func(ctx context.Context) error { return nil }}); err == nil {
+		t.Fatal("expected duplicate check name to be rejected")
+	}
+}
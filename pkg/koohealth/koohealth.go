@@ -0,0 +1,36 @@
+// Package koohealth tracks the state reported by the liveness, readiness and startup probes.
+package koohealth
+
+import "sync/atomic"
+
+// Probes holds the process state behind the startup and readiness probes. It is safe for concurrent use.
+type Probes struct {
+	started  atomic.Bool
+	draining atomic.Bool
+}
+
+// NewProbes returns Probes for a process that has not started yet.
+func NewProbes() *Probes {
+	return &Probes{}
+}
+
+// MarkStarted records that startup has completed and the server is accepting connections.
+func (p *Probes) MarkStarted() {
+	p.started.Store(true)
+}
+
+// MarkDraining records that shutdown has begun. Readiness fails from now on so load balancers stop
+// routing new requests while in-flight requests complete.
+func (p *Probes) MarkDraining() {
+	p.draining.Store(true)
+}
+
+// Started reports whether startup has completed.
+func (p *Probes) Started() bool {
+	return p.started.Load()
+}
+
+// Draining reports whether shutdown has begun.
+func (p *Probes) Draining() bool {
+	return p.draining.Load()
+}
@@ -0,0 +1,101 @@
+package koohttp
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+)
+
+// ErrorDefinition declares an error code clients can receive, see DefineError.
+type ErrorDefinition struct {
+	Code   string `json:"code"`
+	Status int    `json:"status"`
+	// Description explains when the error is returned, it is only used in documentation.
+	Description string `json:"description"`
+	// Message is the client-safe summary sent with every response of the error.
+	Message string `json:"message"`
+}
+
+// catalog holds the error definitions of the process, see ErrorCatalog.
+var catalog struct {
+	mu          sync.Mutex
+	definitions map[string]ErrorDefinition
+	errs        []error
+}
+
+// DefineError adds def to the error catalog and returns its API error. Errors are defined as package
+// variables, so invalid or duplicate definitions are recorded rather than returned and fail
+// CheckErrorCatalog at startup:
+//
+//	var ErrUserNotFound = koohttp.DefineError(koohttp.ErrorDefinition{
+//		Code:        "user_not_found",
+//		Status:      http.StatusNotFound,
+//		Description: "The user does not exist.",
+//		Message:     "The user was not found",
+//	})
+func DefineError(def ErrorDefinition) APIError {
+	return defineError(def)
+}
+
+func defineError(def ErrorDefinition) *APIResponseError {
+	catalog.mu.Lock()
+	defer catalog.mu.Unlock()
+
+	switch {
+	case def.Code == "":
+		catalog.errs = append(catalog.errs, fmt.Errorf("error code is required, got %+v", def))
+	case def.Status < http.StatusBadRequest || def.Status > 599:
+		catalog.errs = append(catalog.errs, fmt.Errorf("error %s has status %d, want 4xx or 5xx", def.Code, def.Status))
+	case def.Message == "":
+		catalog.errs = append(catalog.errs, fmt.Errorf("error %s has no message", def.Code))
+	}
+
+	if _, ok := catalog.definitions[def.Code]; ok {
+		catalog.errs = append(catalog.errs, fmt.Errorf("error %s is already defined", def.Code))
+	} else {
+		if catalog.definitions == nil {
+			catalog.definitions = make(map[string]ErrorDefinition)
+		}
+
+		catalog.definitions[def.Code] = def
+	}
+
+	return &APIResponseError{
+		Status:    def.Status,
+		ErrorCode: def.Code,
+		Message:   def.Message,
+	}
+}
+
+// CheckErrorCatalog returns the invalid and duplicate error definitions.
+func CheckErrorCatalog() error {
+	catalog.mu.Lock()
+	defer catalog.mu.Unlock()
+
+	if len(catalog.errs) > 0 {
+		return fmt.Errorf("invalid error catalog: %w", errors.Join(catalog.errs...))
+	}
+
+	return nil
+}
+
+// ErrorCatalog returns the defined errors ordered by status and code. Only errors of imported packages
+// are defined.
+func ErrorCatalog() []ErrorDefinition {
+	catalog.mu.Lock()
+	defer catalog.mu.Unlock()
+
+	definitions := make([]ErrorDefinition, 0, len(catalog.definitions))
+	for _, def := range catalog.definitions {
+		definitions = append(definitions, def)
+	}
+
+	slices.SortFunc(definitions, func(a, b ErrorDefinition) int {
+		return cmp.Or(cmp.Compare(a.Status, b.Status), cmp.Compare(a.Code, b.Code))
+	})
+
+	return definitions
+}
@@ -0,0 +1,43 @@
+package koohttp_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/kootic/koogo/pkg/koohttp"
+)
+
+// TestErrorCatalog is not parallel as it defines invalid errors in the catalog of the process.
+func TestErrorCatalog(t *testing.T) {
+	koohttp.IsolateErrorCatalog(t)
+
+	if err := koohttp.CheckErrorCatalog(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	catalog := koohttp.ErrorCatalog()
+	for i := 1; i < len(catalog); i++ {
+		if catalog[i-1].Status > catalog[i].Status {
+			t.Fatalf("expected the catalog to be ordered by status, got %+v", catalog)
+		}
+	}
+
+	koohttp.DefineError(koohttp.ErrorDefinition{
+		Code:    koohttp.APIErrorCodeNotFound,
+		Status:  http.StatusNotFound,
+		Message: "Not found again",
+	})
+	koohttp.DefineError(koohttp.ErrorDefinition{Code: "teapot", Status: http.StatusTeapot})
+
+	err := koohttp.CheckErrorCatalog()
+	if err == nil {
+		t.Fatal("expected invalid definitions to fail the catalog check")
+	}
+
+	for _, want := range []string{"error not_found is already defined", "error teapot has no message"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q in %v", want, err)
+		}
+	}
+}
@@ -1,5 +1,7 @@
 package koohttp
 
+import "net/http"
+
 const (
 	APIErrorCodeInternalServerError = "internal_server_error"
 	APIErrorCodeBadRequest          = "bad_request"
@@ -8,8 +10,89 @@ const (
 	APIErrorCodeNotFound            = "not_found"
 	APIErrorCodeRequestTimeout      = "request_timeout"
 	APIErrorCodeConflict            = "conflict"
+	APIErrorCodePayloadTooLarge     = "payload_too_large"
 	APIErrorCodeUnprocessableEntity = "unprocessable_entity"
+	APIErrorCodeValidationFailed    = "validation_failed"
+	APIErrorCodeMalformedBody       = "malformed_body"
+	APIErrorCodeTooManyRequests     = "too_many_requests"
 	APIErrorCodeServiceUnavailable  = "service_unavailable"
+	APIErrorCodeGatewayTimeout      = "gateway_timeout"
+)
+
+// Errors of the response helpers, e.g. NotFound, and the middleware.
+var (
+	ErrInternalServerError = DefineError(ErrorDefinition{
+		Code:        APIErrorCodeInternalServerError,
+		Status:      http.StatusInternalServerError,
+		Description: "An unexpected error occurred, the details are only logged.",
+		Message:     "An unexpected error occurred",
+	})
+	ErrBadRequest = DefineError(ErrorDefinition{
+		Code:        APIErrorCodeBadRequest,
+		Status:      http.StatusBadRequest,
+		Description: "The request is invalid, e.g. it asks for an unknown API version.",
+		Message:     "The request is invalid",
+	})
+	ErrUnauthorized = DefineError(ErrorDefinition{
+		Code:        APIErrorCodeUnauthorized,
+		Status:      http.StatusUnauthorized,
+		Description: "The request is not authenticated.",
+		Message:     "Authentication is required",
+	})
+	ErrForbidden = DefineError(ErrorDefinition{
+		Code:        APIErrorCodeForbidden,
+		Status:      http.StatusForbidden,
+		Description: "The authenticated client is not allowed to perform the request.",
+		Message:     "Access is denied",
+	})
+	ErrNotFound = DefineError(ErrorDefinition{
+		Code:        APIErrorCodeNotFound,
+		Status:      http.StatusNotFound,
+		Description: "The requested resource does not exist.",
+		Message:     "The resource was not found",
+	})
+	ErrRequestTimeout = DefineError(ErrorDefinition{
+		Code:        APIErrorCodeRequestTimeout,
+		Status:      http.StatusRequestTimeout,
+		Description: "The client did not send the request in time.",
+		Message:     "The request timed out",
+	})
+	ErrConflict = DefineError(ErrorDefinition{
+		Code:        APIErrorCodeConflict,
+		Status:      http.StatusConflict,
+		Description: "The request conflicts with the current state of the resource.",
+		Message:     "The request conflicts with the resource",
+	})
+	ErrPayloadTooLarge = DefineError(ErrorDefinition{
+		Code:        APIErrorCodePayloadTooLarge,
+		Status:      http.StatusRequestEntityTooLarge,
+		Description: "The request body is larger than the body limit of its route.",
+		Message:     "The request body is too large",
+	})
+	ErrUnprocessableEntity = DefineError(ErrorDefinition{
+		Code:        APIErrorCodeUnprocessableEntity,
+		Status:      http.StatusUnprocessableEntity,
+		Description: "The request is well-formed but cannot be processed.",
+		Message:     "The request cannot be processed",
+	})
+	ErrTooManyRequests = DefineError(ErrorDefinition{
+		Code:        APIErrorCodeTooManyRequests,
+		Status:      http.StatusTooManyRequests,
+		Description: "The client exceeded the rate limit of the route.",
+		Message:     "Too many requests",
+	})
+	ErrServiceUnavailable = DefineError(ErrorDefinition{
+		Code:        APIErrorCodeServiceUnavailable,
+		Status:      http.StatusServiceUnavailable,
+		Description: "The service is temporarily unable to handle the request.",
+		Message:     "The service is unavailable",
+	})
+	ErrGatewayTimeout = DefineError(ErrorDefinition{
+		Code:        APIErrorCodeGatewayTimeout,
+		Status:      http.StatusGatewayTimeout,
+		Description: "The request did not complete before the timeout of its route.",
+		Message:     "The request took too long to process",
+	})
 )
 
 type APIError interface {
@@ -20,6 +103,14 @@ type APIError interface {
 type APIResponseError struct {
 	Status    int    `json:"status"`
 	ErrorCode string `json:"errorCode"`
+	// Message is the client-safe summary of the error code, see ErrorDefinition.
+	Message string `json:"message,omitempty"`
+	// Detail explains this occurrence of the error to the client.
+	Detail string `json:"detail,omitempty"`
+	// Errors lists the invalid fields of the request.
+	Errors []FieldError `json:"errors,omitempty"`
+	// RequestID identifies the request in logs, it is set when the error is sent, see Error.
+	RequestID string `json:"requestId,omitempty"`
 }
 
 // Error implements the error interface.
@@ -36,6 +127,8 @@ func (e *APIResponseError) HTTPStatus() int {
 	return e.Status
 }
 
+// NewAPIError returns an API error that is not listed in the error catalog. Errors sent to clients
+// should be declared with DefineError instead.
 func NewAPIError(status int, errorCode string) APIError {
 	return &APIResponseError{
 		Status:    status,
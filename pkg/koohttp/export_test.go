@@ -0,0 +1,21 @@
+package koohttp
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+// IsolateErrorCatalog restores the error catalog when t finishes, so tests can define invalid errors.
+func IsolateErrorCatalog(t testing.TB) {
+	catalog.mu.Lock()
+	definitions, errs := maps.Clone(catalog.definitions), slices.Clone(catalog.errs)
+	catalog.mu.Unlock()
+
+	t.Cleanup(func() {
+		catalog.mu.Lock()
+		defer catalog.mu.Unlock()
+
+		catalog.definitions, catalog.errs = definitions, errs
+	})
+}
@@ -1,50 +1,127 @@
 package koohttp
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
+	"reflect"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 )
 
 var (
-	ErrInvalidParamUUID = NewAPIError(http.StatusBadRequest, "invalid_param_uuid")
+	ErrInvalidParamUUID = DefineError(ErrorDefinition{
+		Code:        "invalid_param_uuid",
+		Status:      http.StatusBadRequest,
+		Description: "A path parameter that identifies a resource is not a UUID.",
+		Message:     "The path parameter must be a UUID",
+	})
+	ErrMalformedBody = DefineError(ErrorDefinition{
+		Code:        APIErrorCodeMalformedBody,
+		Status:      http.StatusBadRequest,
+		Description: "The request body cannot be parsed, e.g. it is not valid JSON.",
+		Message:     "The request body is malformed",
+	})
+	ErrInvalidQuery = DefineError(ErrorDefinition{
+		Code:        "invalid_query",
+		Status:      http.StatusBadRequest,
+		Description: "The query string cannot be parsed into the parameters of the route.",
+		Message:     "The query string is invalid",
+	})
+	ErrInvalidParams = DefineError(ErrorDefinition{
+		Code:        "invalid_params",
+		Status:      http.StatusBadRequest,
+		Description: "The path parameters cannot be parsed into the parameters of the route.",
+		Message:     "The path parameters are invalid",
+	})
 )
 
 type WithValidate interface {
 	Validate() error
 }
 
+// WithValidateContext is implemented by DTOs whose validation needs the request context, e.g. to
+// translate messages with ValidationError.RequiredContext.
+type WithValidateContext interface {
+	ValidateContext(ctx context.Context) error
+}
+
+// GetBodyAndValidate parses the body into T and validates it, see validate. Malformed bodies are
+// rejected with ErrMalformedBody and fields of the wrong type with a ValidationError.
 func GetBodyAndValidate[T any](c *fiber.Ctx) (*T, error) {
 	var body T
 
 	if err := c.BodyParser(&body); err != nil {
-		return nil, err
-	}
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			fieldErr := fieldError(c.UserContext(), FieldErrorCodeType, "validation.invalid_type",
+				map[string]string{"type": typeErr.Type.String()})
+
+			var validationErr ValidationError
+			validationErr.Add(typeErr.Field, fieldErr.Code, fieldErr.Message)
 
-	if dto, ok := any(body).(WithValidate); ok {
-		if err := dto.Validate(); err != nil {
-			return nil, err
+			return nil, validationErr.Err()
 		}
+
+		return nil, ErrMalformedBody
+	}
+
+	if err := validate(c.UserContext(), &body, "json"); err != nil {
+		return nil, err
 	}
 
 	return &body, nil
 }
 
+// GetQueryAndValidate parses the query string into T and validates it, see validate.
 func GetQueryAndValidate[T any](c *fiber.Ctx) (*T, error) {
 	var query T
 
 	if err := c.QueryParser(&query); err != nil {
+		return nil, ErrInvalidQuery
+	}
+
+	if err := validate(c.UserContext(), &query, "query"); err != nil {
+		return nil, err
+	}
+
+	return &query, nil
+}
+
+// GetParamsAndValidate parses the route params into T and validates it, see validate.
+func GetParamsAndValidate[T any](c *fiber.Ctx) (*T, error) {
+	var params T
+
+	if err := c.ParamsParser(&params); err != nil {
+		return nil, ErrInvalidParams
+	}
+
+	if err := validate(c.UserContext(), &params, "params"); err != nil {
 		return nil, err
 	}
 
-	if dto, ok := any(query).(WithValidate); ok {
-		if err := dto.Validate(); err != nil {
-			return nil, err
+	return &params, nil
+}
+
+// validate checks the validate tags of v, naming fields after nameTag, then calls its Validate or
+// ValidateContext method when v implements WithValidate or WithValidateContext and the tags are satisfied.
+func validate(ctx context.Context, v any, nameTag string) error {
+	if reflect.TypeOf(v).Elem().Kind() == reflect.Struct {
+		if err := validateStruct(ctx, v, nameTag); err != nil {
+			return err
 		}
 	}
 
-	return &query, nil
+	switch dto := v.(type) {
+	case WithValidateContext:
+		return dto.ValidateContext(ctx)
+	case WithValidate:
+		return dto.Validate()
+	default:
+		return nil
+	}
 }
 
 func GetParamUUID(c *fiber.Ctx, paramKey string) (uuid.UUID, error) {
@@ -0,0 +1,108 @@
+package koohttp
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const MIMEApplicationProblemJSON = "application/problem+json"
+
+// ErrorFormat selects the representation of error responses.
+type ErrorFormat string
+
+const (
+	// ErrorFormatLegacy responds with APIResponseError as application/json.
+	ErrorFormatLegacy ErrorFormat = "legacy"
+	// ErrorFormatProblem responds with ProblemDetails as application/problem+json.
+	ErrorFormatProblem ErrorFormat = "problem"
+)
+
+// ErrorConfig configures how errors are represented in the responses of a request, see SetErrorConfig.
+type ErrorConfig struct {
+	// Format is used unless the client asks for problem details in its Accept header.
+	Format ErrorFormat
+	// TypeBaseURL is joined with the error code to build the type URI of problem details, e.g.
+	// https://example.com/errors/. Problem details have the type about:blank when it is empty.
+	TypeBaseURL string
+}
+
+// errorConfigKey is the key of the ErrorConfig in the request locals.
+type errorConfigKey struct{}
+
+// SetErrorConfig sets how errors are represented in the responses of the request.
+func SetErrorConfig(c *fiber.Ctx, cfg ErrorConfig) {
+	c.Locals(errorConfigKey{}, cfg)
+}
+
+func getErrorConfig(c *fiber.Ctx) ErrorConfig {
+	cfg, _ := c.Locals(errorConfigKey{}).(ErrorConfig)
+	return cfg
+}
+
+// ProblemDetails is the RFC 9457 representation of an APIResponseError. The title is the message of the
+// error code, see ErrorDefinition, and the error code, request ID and field errors are extension members.
+type ProblemDetails struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`
+	Instance  string       `json:"instance,omitempty"`
+	ErrorCode string       `json:"errorCode"`
+	RequestID string       `json:"requestId,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+}
+
+// NewProblemDetails returns the problem details of err for the request at instance.
+func NewProblemDetails(err *APIResponseError, instance string, typeBaseURL string) *ProblemDetails {
+	problemType := "about:blank"
+	if typeBaseURL != "" {
+		problemType = typeBaseURL + err.ErrorCode
+	}
+
+	title := err.Message
+	if title == "" {
+		title = http.StatusText(err.Status)
+	}
+
+	return &ProblemDetails{
+		Type:      problemType,
+		Title:     title,
+		Status:    err.Status,
+		Detail:    err.Detail,
+		Instance:  instance,
+		ErrorCode: err.ErrorCode,
+		RequestID: err.RequestID,
+		Errors:    err.Errors,
+	}
+}
+
+// wantsProblem reports whether the error response of the request is represented as problem details. An
+// Accept header that lists application/problem+json selects them and one that lists application/json
+// without it selects the legacy representation, so clients can ask for either regardless of the
+// configured format.
+func wantsProblem(c *fiber.Ctx) bool {
+	acceptsJSON := false
+
+	for _, mediaRange := range strings.Split(c.Get(fiber.HeaderAccept), ",") {
+		mediaType, _, err := mime.ParseMediaType(mediaRange)
+		if err != nil {
+			continue
+		}
+
+		switch mediaType {
+		case MIMEApplicationProblemJSON:
+			return true
+		case fiber.MIMEApplicationJSON:
+			acceptsJSON = true
+		}
+	}
+
+	if acceptsJSON {
+		return false
+	}
+
+	return getErrorConfig(c).Format == ErrorFormatProblem
+}
@@ -0,0 +1,78 @@
+package koohttp_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/kootic/koogo/pkg/koohttp"
+)
+
+func TestErrorNegotiation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		format          koohttp.ErrorFormat
+		accept          string
+		wantContentType string
+	}{
+		{
+			name:            "legacy by default",
+			format:          koohttp.ErrorFormatLegacy,
+			wantContentType: fiber.MIMEApplicationJSON,
+		},
+		{
+			name:            "client asks for problem details",
+			format:          koohttp.ErrorFormatLegacy,
+			accept:          "application/problem+json",
+			wantContentType: koohttp.MIMEApplicationProblemJSON,
+		},
+		{
+			name:            "problem details by config",
+			format:          koohttp.ErrorFormatProblem,
+			accept:          "*/*",
+			wantContentType: koohttp.MIMEApplicationProblemJSON,
+		},
+		{
+			name:            "client asks for the legacy shape",
+			format:          koohttp.ErrorFormatProblem,
+			accept:          "application/json",
+			wantContentType: fiber.MIMEApplicationJSON,
+		},
+		{
+			name:            "problem details win when both are listed",
+			format:          koohttp.ErrorFormatLegacy,
+			accept:          "application/json, application/problem+json;q=0.9",
+			wantContentType: koohttp.MIMEApplicationProblemJSON,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			app := fiber.New()
+			app.Get("/", func(c *fiber.Ctx) error {
+				koohttp.SetErrorConfig(c, koohttp.ErrorConfig{Format: tt.format})
+				return koohttp.NotFound(c)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.accept != "" {
+				req.Header.Set(fiber.HeaderAccept, tt.accept)
+			}
+
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := resp.Header.Get(fiber.HeaderContentType); got != tt.wantContentType {
+				t.Fatalf("expected content type %s, got %s", tt.wantContentType, got)
+			}
+		})
+	}
+}
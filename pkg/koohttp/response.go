@@ -1,9 +1,13 @@
 package koohttp
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gofiber/fiber/v2"
+
+	"github.com/kootic/koogo/pkg/kooctx"
+	"github.com/kootic/koogo/pkg/kooi18n"
 )
 
 // There is intentionally not a function to return an InternalServerError,
@@ -22,34 +26,81 @@ func SuccessNoContent(c *fiber.Ctx) error {
 	return c.Status(http.StatusNoContent).JSON(nil)
 }
 
+// Error responds with apiErr and its status, adding the ID of the request to the body so support can
+// match reports to logs. The message is translated into the locale of the request, see kooi18n, and the
+// error code is added to the request's log line, see kooctx.AddField.
+//
+// Errors are represented as ProblemDetails when the client accepts application/problem+json or the
+// request's ErrorConfig selects them, and as APIResponseError otherwise.
+func Error(c *fiber.Ctx, apiErr APIError) error {
+	var (
+		respErr       *APIResponseError
+		validationErr *ValidationError
+	)
+
+	switch {
+	case errors.As(apiErr, &validationErr):
+		respErr = validationErr.responseError()
+	case !errors.As(apiErr, &respErr):
+		kooctx.AddField(c.UserContext(), "error_code", apiErr.Error())
+		return c.Status(apiErr.HTTPStatus()).JSON(apiErr)
+	}
+
+	kooctx.AddField(c.UserContext(), "error_code", respErr.ErrorCode)
+
+	// Errors are often shared values, so the request ID and translated message are set on a copy
+	sent := *respErr
+	sent.RequestID = kooctx.GetContextRequestID(c.UserContext())
+	sent.Message = kooi18n.Translate(c.UserContext(), "errors."+respErr.ErrorCode, respErr.Message, nil)
+
+	if wantsProblem(c) {
+		problem := NewProblemDetails(&sent, c.Path(), getErrorConfig(c).TypeBaseURL)
+		return c.Status(apiErr.HTTPStatus()).JSON(problem, MIMEApplicationProblemJSON)
+	}
+
+	return c.Status(apiErr.HTTPStatus()).JSON(&sent)
+}
+
 func BadRequest(c *fiber.Ctx) error {
-	return c.Status(http.StatusBadRequest).JSON(NewAPIError(http.StatusBadRequest, APIErrorCodeBadRequest))
+	return Error(c, ErrBadRequest)
 }
 
 func Unauthorized(c *fiber.Ctx) error {
-	return c.Status(http.StatusUnauthorized).JSON(NewAPIError(http.StatusUnauthorized, APIErrorCodeUnauthorized))
+	return Error(c, ErrUnauthorized)
 }
 
 func Forbidden(c *fiber.Ctx) error {
-	return c.Status(http.StatusForbidden).JSON(NewAPIError(http.StatusForbidden, APIErrorCodeForbidden))
+	return Error(c, ErrForbidden)
 }
 
 func NotFound(c *fiber.Ctx) error {
-	return c.Status(http.StatusNotFound).JSON(NewAPIError(http.StatusNotFound, APIErrorCodeNotFound))
+	return Error(c, ErrNotFound)
 }
 
 func RequestTimeout(c *fiber.Ctx) error {
-	return c.Status(http.StatusRequestTimeout).JSON(NewAPIError(http.StatusRequestTimeout, APIErrorCodeRequestTimeout))
+	return Error(c, ErrRequestTimeout)
 }
 
 func Conflict(c *fiber.Ctx) error {
-	return c.Status(http.StatusConflict).JSON(NewAPIError(http.StatusConflict, APIErrorCodeConflict))
+	return Error(c, ErrConflict)
+}
+
+func PayloadTooLarge(c *fiber.Ctx) error {
+	return Error(c, ErrPayloadTooLarge)
 }
 
 func UnprocessableEntity(c *fiber.Ctx) error {
-	return c.Status(http.StatusUnprocessableEntity).JSON(NewAPIError(http.StatusUnprocessableEntity, APIErrorCodeUnprocessableEntity))
+	return Error(c, ErrUnprocessableEntity)
+}
+
+func TooManyRequests(c *fiber.Ctx) error {
+	return Error(c, ErrTooManyRequests)
 }
 
 func ServiceUnavailable(c *fiber.Ctx) error {
-	return c.Status(http.StatusServiceUnavailable).JSON(NewAPIError(http.StatusServiceUnavailable, APIErrorCodeServiceUnavailable))
+	return Error(c, ErrServiceUnavailable)
+}
+
+func GatewayTimeout(c *fiber.Ctx) error {
+	return Error(c, ErrGatewayTimeout)
 }
@@ -0,0 +1,274 @@
+package koohttp
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/mail"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/kootic/koogo/pkg/kooi18n"
+)
+
+const tagValidate = "validate"
+
+// Codes of the field errors recorded by validate tags, required uses FieldErrorCodeRequired.
+const (
+	FieldErrorCodeMin   = "min"
+	FieldErrorCodeMax   = "max"
+	FieldErrorCodeEmail = "email"
+	FieldErrorCodeUUID  = "uuid"
+	FieldErrorCodeOneOf = "oneof"
+)
+
+// fieldMessages are the English messages of field errors keyed by their translation key, see kooi18n.
+var fieldMessages = map[string]string{
+	"validation.required":     "is required",
+	"validation.min":          "must be at least {limit}",
+	"validation.min_length":   "must be at least {limit} characters",
+	"validation.min_items":    "must be at least {limit} items",
+	"validation.max":          "must be at most {limit}",
+	"validation.max_length":   "must be at most {limit} characters",
+	"validation.max_items":    "must be at most {limit} items",
+	"validation.email":        "must be a valid email address",
+	"validation.uuid":         "must be a valid UUID",
+	"validation.oneof":        "must be one of {options}",
+	"validation.invalid_type": "must be of type {type}",
+}
+
+// FieldMessages returns the English messages of field errors keyed by their translation key, e.g. to
+// check that translations cover every key.
+func FieldMessages() map[string]string {
+	return maps.Clone(fieldMessages)
+}
+
+// fieldError returns a field error with code and the message with key, translated into the locale of ctx.
+func fieldError(ctx context.Context, code, key string, params map[string]string) FieldError {
+	return FieldError{Code: code, Message: kooi18n.Translate(ctx, key, fieldMessages[key], params)}
+}
+
+// rule is a parsed rule of a validate tag, e.g. "max=100".
+type rule struct {
+	name string
+	arg  string
+	// limit is the parsed argument of min and max
+	limit float64
+}
+
+// structField is a field of a request struct with the rules of its validate tag.
+type structField struct {
+	index []int
+	name  string
+	rules []rule
+}
+
+// structFields caches the fields of request structs by type and name tag.
+var structFields sync.Map
+
+type structFieldsKey struct {
+	t       reflect.Type
+	nameTag string
+}
+
+// validateStruct checks the validate tags of the fields of v, a pointer to a struct, and returns a
+// ValidationError listing every invalid field. Fields are named after nameTag, e.g. "json", and
+// nested structs are validated with their path, e.g. "address.city" or "items[0].name".
+//
+// Supported rules are required, min=n and max=n (the value of numbers and the length of strings,
+// slices and maps), email, uuid and oneof=a b c. Rules other than required only apply to fields that
+// are set, so optional fields can be omitted, and fields promoted through a nil embedded pointer are
+// unset. Messages are translated into the locale of ctx.
+func validateStruct(ctx context.Context, v any, nameTag string) error {
+	var errs ValidationError
+	if err := validateValue(ctx, reflect.ValueOf(v).Elem(), "", nameTag, &errs); err != nil {
+		return err
+	}
+
+	return errs.Err()
+}
+
+func validateValue(ctx context.Context, v reflect.Value, path, nameTag string, errs *ValidationError) error {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if !v.IsNil() {
+			return validateValue(ctx, v.Elem(), path, nameTag, errs)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			if err := validateValue(ctx, v.Index(i), fmt.Sprintf("%s[%d]", path, i), nameTag, errs); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		fields, err := fieldsOf(v.Type(), nameTag)
+		if err != nil {
+			return err
+		}
+
+		for _, field := range fields {
+			name := field.name
+			if path != "" {
+				name = path + "." + name
+			}
+
+			// Fields promoted through a nil embedded pointer are unset
+			value, err := v.FieldByIndexErr(field.index)
+			if err != nil {
+				value = reflect.Zero(v.Type().FieldByIndex(field.index).Type)
+			}
+
+			if fieldErr, ok := checkRules(ctx, value, field.rules); !ok {
+				errs.Add(name, fieldErr.Code, fieldErr.Message)
+				continue
+			}
+
+			if err := validateValue(ctx, value, name, nameTag, errs); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkRules returns the error of the first rule value breaks.
+func checkRules(ctx context.Context, value reflect.Value, rules []rule) (FieldError, bool) {
+	if value.IsZero() {
+		if slices.ContainsFunc(rules, func(r rule) bool { return r.name == "required" }) {
+			return fieldError(ctx, FieldErrorCodeRequired, "validation.required", nil), false
+		}
+
+		return FieldError{}, true
+	}
+
+	for value.Kind() == reflect.Pointer {
+		value = value.Elem()
+	}
+
+	for _, r := range rules {
+		switch r.name {
+		case "min":
+			if size, suffix := sizeOf(value); size < r.limit {
+				return fieldError(ctx, FieldErrorCodeMin, "validation.min"+suffix, map[string]string{"limit": r.arg}), false
+			}
+		case "max":
+			if size, suffix := sizeOf(value); size > r.limit {
+				return fieldError(ctx, FieldErrorCodeMax, "validation.max"+suffix, map[string]string{"limit": r.arg}), false
+			}
+		case "email":
+			address, err := mail.ParseAddress(value.String())
+			if err != nil || address.Address != value.String() {
+				return fieldError(ctx, FieldErrorCodeEmail, "validation.email", nil), false
+			}
+		case "uuid":
+			if _, err := uuid.Parse(value.String()); err != nil {
+				return fieldError(ctx, FieldErrorCodeUUID, "validation.uuid", nil), false
+			}
+		case "oneof":
+			options := strings.Fields(r.arg)
+			if !slices.Contains(options, fmt.Sprint(value.Interface())) {
+				params := map[string]string{"options": strings.Join(options, ", ")}
+				return fieldError(ctx, FieldErrorCodeOneOf, "validation.oneof", params), false
+			}
+		}
+	}
+
+	return FieldError{}, true
+}
+
+// sizeOf returns what min and max compare for value: the value of numbers, the number of characters of
+// strings and the length of slices and maps, with the suffix of the message key of their unit.
+func sizeOf(value reflect.Value) (float64, string) {
+	switch value.Kind() {
+	case reflect.String:
+		return float64(utf8.RuneCountInString(value.String())), "_length"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return float64(value.Len()), "_items"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), ""
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(value.Uint()), ""
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), ""
+	default:
+		return 0, ""
+	}
+}
+
+// fieldsOf returns the exported fields of t with their validate rules, named after nameTag.
+func fieldsOf(t reflect.Type, nameTag string) ([]structField, error) {
+	key := structFieldsKey{t: t, nameTag: nameTag}
+	if cached, ok := structFields.Load(key); ok {
+		return cached.([]structField), nil
+	}
+
+	var fields []structField
+
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() || f.Anonymous {
+			continue
+		}
+
+		name, _, _ := strings.Cut(f.Tag.Get(nameTag), ",")
+		if name == "-" {
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+
+		rules, err := parseRules(f.Tag.Get(tagValidate))
+		if err != nil {
+			return nil, fmt.Errorf("koohttp: field %s of %s: %w", f.Name, t, err)
+		}
+
+		fields = append(fields, structField{index: f.Index, name: name, rules: rules})
+	}
+
+	structFields.Store(key, fields)
+
+	return fields, nil
+}
+
+// parseRules parses a validate tag, e.g. "required,max=100".
+func parseRules(tag string) ([]rule, error) {
+	if tag == "" {
+		return nil, nil
+	}
+
+	var rules []rule
+
+	for _, part := range strings.Split(tag, ",") {
+		name, arg, _ := strings.Cut(strings.TrimSpace(part), "=")
+		r := rule{name: name, arg: arg}
+
+		switch name {
+		case "required", "email", "uuid":
+		case "min", "max":
+			limit, err := strconv.ParseFloat(arg, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s rule %q", name, part)
+			}
+
+			r.limit = limit
+		case "oneof":
+			if strings.TrimSpace(arg) == "" {
+				return nil, fmt.Errorf("oneof rule requires options")
+			}
+		default:
+			return nil, fmt.Errorf("unknown validation rule %q", name)
+		}
+
+		rules = append(rules, r)
+	}
+
+	return rules, nil
+}
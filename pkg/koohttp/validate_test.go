@@ -0,0 +1,174 @@
+package koohttp_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/kootic/koogo/pkg/koohttp"
+)
+
+type address struct {
+	City string `json:"city" validate:"required"`
+}
+
+type createRequest struct {
+	Name      string    `json:"name"      validate:"required,min=2,max=5"`
+	Email     string    `json:"email"     validate:"email"`
+	ID        string    `json:"id"        validate:"uuid"`
+	Plan      string    `json:"plan"      validate:"oneof=free pro"`
+	Age       int       `json:"age"       validate:"max=130"`
+	Tags      []string  `json:"tags"      validate:"max=2"`
+	Addresses []address `json:"addresses"`
+}
+
+func (r *createRequest) Validate() error {
+	return errors.New("hook called")
+}
+
+func TestGetBodyAndValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		body     string
+		want     []koohttp.FieldError
+		wantHook bool
+	}{
+		{
+			name:     "valid body calls the hook",
+			body:     `{"name": "ann", "email": "ann@example.com", "plan": "pro"}`,
+			wantHook: true,
+		},
+		{
+			name: "every invalid field is reported",
+			body: `{"email": "Ann <ann@example.com>", "id": "1", "plan": "team", "age": 200, "tags": ["a", "b", "c"]}`,
+			want: []koohttp.FieldError{
+				{Field: "name", Code: koohttp.FieldErrorCodeRequired, Message: "is required"},
+				{Field: "email", Code: koohttp.FieldErrorCodeEmail, Message: "must be a valid email address"},
+				{Field: "id", Code: koohttp.FieldErrorCodeUUID, Message: "must be a valid UUID"},
+				{Field: "plan", Code: koohttp.FieldErrorCodeOneOf, Message: "must be one of free, pro"},
+				{Field: "age", Code: koohttp.FieldErrorCodeMax, Message: "must be at most 130"},
+				{Field: "tags", Code: koohttp.FieldErrorCodeMax, Message: "must be at most 2 items"},
+			},
+		},
+		{
+			name: "string length and nested fields",
+			body: `{"name": "ännchen", "addresses": [{"city": "Oslo"}, {}]}`,
+			want: []koohttp.FieldError{
+				{Field: "name", Code: koohttp.FieldErrorCodeMax, Message: "must be at most 5 characters"},
+				{Field: "addresses[1].city", Code: koohttp.FieldErrorCodeRequired, Message: "is required"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var err error
+
+			app := fiber.New()
+			app.Post("/", func(c *fiber.Ctx) error {
+				_, err = koohttp.GetBodyAndValidate[createRequest](c)
+				return nil
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+
+			if _, testErr := app.Test(req); testErr != nil {
+				t.Fatalf("unexpected error: %v", testErr)
+			}
+
+			if tt.wantHook {
+				if err == nil || err.Error() != "hook called" {
+					t.Fatalf("expected the Validate hook to be called, got %v", err)
+				}
+
+				return
+			}
+
+			var validationErr *koohttp.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("expected a ValidationError, got %v", err)
+			}
+
+			if !reflect.DeepEqual(validationErr.Errors, tt.want) {
+				t.Fatalf("expected field errors %+v, got %+v", tt.want, validationErr.Errors)
+			}
+		})
+	}
+}
+
+// Audit is exported as the JSON decoder only allocates embedded pointers to exported structs.
+type Audit struct {
+	Reason string `json:"reason" validate:"required"`
+	Note   string `json:"note"   validate:"max=3"`
+}
+
+type auditedRequest struct {
+	*Audit
+
+	Name string `json:"name" validate:"required"`
+}
+
+func TestGetBodyAndValidateEmbeddedPointer(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		body string
+		want []koohttp.FieldError
+	}{
+		{
+			name: "nil embedded pointer",
+			body: `{"name": "ann"}`,
+			want: []koohttp.FieldError{
+				{Field: "reason", Code: koohttp.FieldErrorCodeRequired, Message: "is required"},
+			},
+		},
+		{
+			name: "set embedded pointer",
+			body: `{"name": "ann", "reason": "typo", "note": "long"}`,
+			want: []koohttp.FieldError{
+				{Field: "note", Code: koohttp.FieldErrorCodeMax, Message: "must be at most 3 characters"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var err error
+
+			app := fiber.New()
+			app.Post("/", func(c *fiber.Ctx) error {
+				_, err = koohttp.GetBodyAndValidate[auditedRequest](c)
+				return nil
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+
+			if _, testErr := app.Test(req); testErr != nil {
+				t.Fatalf("unexpected error: %v", testErr)
+			}
+
+			var validationErr *koohttp.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("expected a ValidationError, got %v", err)
+			}
+
+			if !reflect.DeepEqual(validationErr.Errors, tt.want) {
+				t.Fatalf("expected field errors %+v, got %+v", tt.want, validationErr.Errors)
+			}
+		})
+	}
+}
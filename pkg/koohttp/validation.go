@@ -0,0 +1,101 @@
+package koohttp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// Codes of common field errors.
+const (
+	FieldErrorCodeRequired = "required"
+	FieldErrorCodeInvalid  = "invalid"
+	FieldErrorCodeType     = "invalid_type"
+)
+
+// errValidationFailed is the error responded with for a ValidationError.
+var errValidationFailed = defineError(ErrorDefinition{
+	Code:        APIErrorCodeValidationFailed,
+	Status:      http.StatusUnprocessableEntity,
+	Description: "Fields of the request are missing or invalid, they are listed in errors.",
+	Message:     "The request has invalid fields",
+})
+
+// FieldError describes an invalid field of a request.
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ValidationError lists the invalid fields of a request and is responded to with 422. DTOs accumulate
+// field errors in ValidateContext, or Validate, and return Err:
+//
+//	var errs koohttp.ValidationError
+//	errs.RequiredContext(ctx, "firstName", r.FirstName)
+//	errs.Check(len(r.FirstName) <= 50, "firstName", koohttp.FieldErrorCodeInvalid, "must be at most 50 characters")
+//
+//	return errs.Err()
+type ValidationError struct {
+	Errors []FieldError
+}
+
+// Add records an error for field.
+func (e *ValidationError) Add(field, code, message string) {
+	e.Errors = append(e.Errors, FieldError{Field: field, Code: code, Message: message})
+}
+
+// Check records an error for field when ok is false.
+func (e *ValidationError) Check(ok bool, field, code, message string) {
+	if !ok {
+		e.Add(field, code, message)
+	}
+}
+
+// Required records an error for field when value is nil or the zero value. The message is in English,
+// see RequiredContext.
+func (e *ValidationError) Required(field string, value any) {
+	e.RequiredContext(context.Background(), field, value)
+}
+
+// RequiredContext records an error for field when value is nil or the zero value, with the message
+// translated into the locale of ctx, see kooi18n.
+func (e *ValidationError) RequiredContext(ctx context.Context, field string, value any) {
+	if value == nil || reflect.ValueOf(value).IsZero() {
+		fieldErr := fieldError(ctx, FieldErrorCodeRequired, "validation.required", nil)
+		e.Add(field, fieldErr.Code, fieldErr.Message)
+	}
+}
+
+// Err returns the validation error, or nil when no field errors were recorded.
+func (e *ValidationError) Err() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+
+	return e
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, fieldErr := range e.Errors {
+		messages[i] = fmt.Sprintf("%s %s", fieldErr.Field, fieldErr.Message)
+	}
+
+	return APIErrorCodeValidationFailed + ": " + strings.Join(messages, ", ")
+}
+
+func (e *ValidationError) HTTPStatus() int {
+	return http.StatusUnprocessableEntity
+}
+
+// responseError returns the error as it is responded with.
+func (e *ValidationError) responseError() *APIResponseError {
+	respErr := *errValidationFailed
+	respErr.Errors = e.Errors
+
+	return &respErr
+}
@@ -0,0 +1,88 @@
+package koohttp_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/kootic/koogo/pkg/kooctx"
+	"github.com/kootic/koogo/pkg/koohttp"
+)
+
+type germanTranslator struct{}
+
+func (germanTranslator) Locale() string {
+	return "de"
+}
+
+func (germanTranslator) Translate(key string) (string, bool) {
+	message, ok := map[string]string{"validation.required": "ist erforderlich"}[key]
+	return message, ok
+}
+
+func TestValidationErrorRequired(t *testing.T) {
+	t.Parallel()
+
+	var (
+		errs    koohttp.ValidationError
+		nilUser *struct{}
+	)
+
+	errs.Required("name", nil)
+	errs.Required("owner", nilUser)
+	errs.Required("age", 30)
+	errs.RequiredContext(kooctx.SetContextTranslator(context.Background(), germanTranslator{}), "email", "")
+
+	want := []koohttp.FieldError{
+		{Field: "name", Code: koohttp.FieldErrorCodeRequired, Message: "is required"},
+		{Field: "owner", Code: koohttp.FieldErrorCodeRequired, Message: "is required"},
+		{Field: "email", Code: koohttp.FieldErrorCodeRequired, Message: "ist erforderlich"},
+	}
+
+	if !reflect.DeepEqual(errs.Errors, want) {
+		t.Fatalf("expected field errors %+v, got %+v", want, errs.Errors)
+	}
+}
+
+type updateRequest struct {
+	Name string `json:"name"`
+}
+
+func (r *updateRequest) ValidateContext(ctx context.Context) error {
+	var errs koohttp.ValidationError
+	errs.RequiredContext(ctx, "name", r.Name)
+
+	return errs.Err()
+}
+
+func TestGetBodyAndValidateContext(t *testing.T) {
+	t.Parallel()
+
+	var err error
+
+	app := fiber.New()
+	app.Post("/", func(c *fiber.Ctx) error {
+		c.SetUserContext(kooctx.SetContextTranslator(c.UserContext(), germanTranslator{}))
+		_, err = koohttp.GetBodyAndValidate[updateRequest](c)
+
+		return nil
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{}`))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+
+	if _, testErr := app.Test(req); testErr != nil {
+		t.Fatalf("unexpected error: %v", testErr)
+	}
+
+	var validationErr *koohttp.ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Errors[0].Message != "ist erforderlich" {
+		t.Fatalf("expected a translated ValidationError from ValidateContext, got %v", err)
+	}
+}
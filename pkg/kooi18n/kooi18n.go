@@ -0,0 +1,183 @@
+// Package kooi18n translates messages into the locale the client asks for in its Accept-Language header.
+package kooi18n
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"golang.org/x/text/language"
+
+	"github.com/kootic/koogo/pkg/kooctx"
+)
+
+// Bundle holds the messages of every supported locale.
+type Bundle struct {
+	// locales lists the supported locales, starting with the fallback locale
+	locales  []language.Tag
+	matcher  language.Matcher
+	messages map[language.Tag]map[string]string
+}
+
+// NewBundle loads the translation files at the root of fsys, one per locale named after its BCP 47 tag,
+// e.g. de.json or pt-BR.toml. Nested objects and tables are flattened into dotted keys, so
+// {"errors": {"user_not_found": "..."}} defines the key errors.user_not_found.
+//
+// Clients that do not accept any of the locales get the fallback locale, whose messages are usually
+// built into the code and need no file. Messages missing from a locale fall back the same way.
+func NewBundle(fsys fs.FS, fallback language.Tag) (*Bundle, error) {
+	b := &Bundle{
+		locales:  []language.Tag{fallback},
+		messages: map[language.Tag]map[string]string{fallback: {}},
+	}
+
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read translations: %w", err)
+	}
+
+	for _, entry := range entries {
+		ext := path.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".json" && ext != ".toml") {
+			continue
+		}
+
+		tag, err := language.Parse(strings.TrimSuffix(entry.Name(), ext))
+		if err != nil {
+			return nil, fmt.Errorf("translation file %s is not named after a locale: %w", entry.Name(), err)
+		}
+
+		data, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read translation file %s: %w", entry.Name(), err)
+		}
+
+		var raw map[string]any
+		if ext == ".json" {
+			err = json.Unmarshal(data, &raw)
+		} else {
+			err = toml.Unmarshal(data, &raw)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse translation file %s: %w", entry.Name(), err)
+		}
+
+		messages, ok := b.messages[tag]
+		if !ok {
+			messages = make(map[string]string)
+			b.messages[tag] = messages
+			b.locales = append(b.locales, tag)
+		}
+
+		if err := flatten("", raw, messages); err != nil {
+			return nil, fmt.Errorf("invalid translation file %s: %w", entry.Name(), err)
+		}
+	}
+
+	b.matcher = language.NewMatcher(b.locales)
+
+	return b, nil
+}
+
+// flatten adds the messages of raw to messages, joining the keys of nested maps with dots.
+func flatten(prefix string, raw map[string]any, messages map[string]string) error {
+	for key, value := range raw {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		switch value := value.(type) {
+		case string:
+			if _, ok := messages[key]; ok {
+				return fmt.Errorf("message %s is defined twice", key)
+			}
+
+			messages[key] = value
+		case map[string]any:
+			if err := flatten(key, value, messages); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("message %s must be a string, got %T", key, value)
+		}
+	}
+
+	return nil
+}
+
+// Locales returns the supported locales, starting with the fallback locale.
+func (b *Bundle) Locales() []language.Tag {
+	return slices.Clone(b.locales)
+}
+
+// Messages returns the messages translated into locale, keyed by message key.
+func (b *Bundle) Messages(locale language.Tag) map[string]string {
+	return maps.Clone(b.messages[locale])
+}
+
+// Localizer returns the localizer of the supported locale that best matches acceptLanguage, the value
+// of an Accept-Language header.
+func (b *Bundle) Localizer(acceptLanguage string) *Localizer {
+	_, index := language.MatchStrings(b.matcher, acceptLanguage)
+	locale := b.locales[index]
+
+	return &Localizer{
+		locale:   locale,
+		messages: b.messages[locale],
+		fallback: b.messages[b.locales[0]],
+	}
+}
+
+// Localizer translates messages into a locale, it implements kooctx.Translator.
+type Localizer struct {
+	locale   language.Tag
+	messages map[string]string
+	fallback map[string]string
+}
+
+// Locale returns the BCP 47 tag of the locale, e.g. "de".
+func (l *Localizer) Locale() string {
+	return l.locale.String()
+}
+
+// Translate returns the message with key in the locale, or in the fallback locale when it is missing.
+func (l *Localizer) Translate(key string) (string, bool) {
+	if message, ok := l.messages[key]; ok {
+		return message, true
+	}
+
+	message, ok := l.fallback[key]
+
+	return message, ok
+}
+
+// Translate returns the message with key translated by the translator stored in ctx, see
+// kooctx.SetContextTranslator, or fallback when it has no such message. Placeholders in braces are
+// replaced with params, e.g. "must be at most {limit}" with {"limit": "100"}.
+func Translate(ctx context.Context, key, fallback string, params map[string]string) string {
+	message := fallback
+
+	if translator, ok := kooctx.GetContextTranslator(ctx); ok {
+		if translated, ok := translator.Translate(key); ok {
+			message = translated
+		}
+	}
+
+	if len(params) == 0 {
+		return message
+	}
+
+	replacements := make([]string, 0, 2*len(params))
+	for name, value := range params {
+		replacements = append(replacements, "{"+name+"}", value)
+	}
+
+	return strings.NewReplacer(replacements...).Replace(message)
+}
@@ -0,0 +1,127 @@
+package kooi18n_test
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"golang.org/x/text/language"
+
+	"github.com/kootic/koogo/pkg/kooctx"
+	"github.com/kootic/koogo/pkg/kooi18n"
+)
+
+func TestBundle(t *testing.T) {
+	t.Parallel()
+
+	bundle, err := kooi18n.NewBundle(fstest.MapFS{
+		"de.json":    {Data: []byte(`{"errors": {"not_found": "Nicht gefunden"}, "greeting": "Hallo {name}"}`)},
+		"pt-BR.toml": {Data: []byte("[errors]\nnot_found = \"Não encontrado\"\n")},
+		"README.md":  {Data: []byte("ignored")},
+	}, language.English)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		key            string
+		wantLocale     string
+		want           string
+	}{
+		{
+			name:           "exact match",
+			acceptLanguage: "de",
+			key:            "errors.not_found",
+			wantLocale:     "de",
+			want:           "Nicht gefunden",
+		},
+		{
+			name:           "regional variant and quality values",
+			acceptLanguage: "fr, de-CH;q=0.8",
+			key:            "errors.not_found",
+			wantLocale:     "de",
+			want:           "Nicht gefunden",
+		},
+		{
+			name:           "unsupported locale falls back",
+			acceptLanguage: "ja",
+			key:            "errors.not_found",
+			wantLocale:     "en",
+			want:           "Not found",
+		},
+		{
+			name:           "missing message falls back",
+			acceptLanguage: "pt-BR, de;q=0.5",
+			key:            "greeting",
+			wantLocale:     "pt-BR",
+			want:           "Hello Ann",
+		},
+		{
+			name:           "params",
+			acceptLanguage: "de",
+			key:            "greeting",
+			wantLocale:     "de",
+			want:           "Hallo Ann",
+		},
+	}
+
+	fallbacks := map[string]string{
+		"errors.not_found": "Not found",
+		"greeting":         "Hello {name}",
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			localizer := bundle.Localizer(tt.acceptLanguage)
+			if localizer.Locale() != tt.wantLocale {
+				t.Fatalf("expected locale %s, got %s", tt.wantLocale, localizer.Locale())
+			}
+
+			ctx := kooctx.SetContextTranslator(context.Background(), localizer)
+
+			got := kooi18n.Translate(ctx, tt.key, fallbacks[tt.key], map[string]string{"name": "Ann"})
+			if got != tt.want {
+				t.Fatalf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNewBundleInvalidFiles(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		files fstest.MapFS
+	}{
+		{
+			name:  "file not named after a locale",
+			files: fstest.MapFS{"messages.json": {Data: []byte(`{}`)}},
+		},
+		{
+			name:  "message that is not a string",
+			files: fstest.MapFS{"de.json": {Data: []byte(`{"errors": {"not_found": 404}}`)}},
+		},
+		{
+			name: "message defined twice",
+			files: fstest.MapFS{
+				"de.json": {Data: []byte(`{"errors": {"not_found": "Nicht gefunden"}}`)},
+				"de.toml": {Data: []byte("[errors]\nnot_found = \"Nicht gefunden\"\n")},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := kooi18n.NewBundle(tt.files, language.English); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+// Package koolifecycle starts and stops application components in dependency order.
+package koolifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kootic/koogo/pkg/kooctx"
+)
+
+// Hook starts or stops a component.
+type Hook func(ctx context.Context) error
+
+// Component is a unit of infrastructure with an optional Start and Stop hook.
+type Component struct {
+	// Name identifies the component in dependencies, logs and errors and must be unique.
+	Name string
+	// DependsOn lists the names of components that must be started before and stopped after this one.
+	DependsOn []string
+	Start     Hook
+	Stop      Hook
+	// StartTimeout and StopTimeout bound the hooks, zero means the caller's context is used as is.
+	StartTimeout time.Duration
+	StopTimeout  time.Duration
+}
+
+// Manager starts components in dependency order and stops them in reverse order.
+// Components without dependencies between them keep their registration order.
+type Manager struct {
+	mu         sync.Mutex
+	components []Component
+	started    []Component
+}
+
+// NewManager creates an empty Manager.
+func NewManager() *Manager {
+	return &Manager{}
+}
+
+// Register adds a component. Components must be registered before Start.
+func (m *Manager) Register(component Component) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if component.Name == "" {
+		return errors.New("component name is required")
+	}
+
+	for _, c := range m.components {
+		if c.Name == component.Name {
+			return fmt.Errorf("component %s is already registered", component.Name)
+		}
+	}
+
+	m.components = append(m.components, component)
+
+	return nil
+}
+
+// Start starts every component after its dependencies. When a component fails to start,
+// the components started so far are stopped in reverse order and the error is returned.
+// Components are logged with the logger in ctx, see kooctx.SetContextLogger.
+func (m *Manager) Start(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ordered, err := m.order()
+	if err != nil {
+		return err
+	}
+
+	logger := kooctx.GetContextLogger(ctx)
+
+	for _, component := range ordered {
+		if err := runHook(ctx, component.Start, component.StartTimeout); err != nil {
+			startErr := fmt.Errorf("failed to start %s: %w", component.Name, err)
+
+			return errors.Join(startErr, m.stop(ctx))
+		}
+
+		m.started = append(m.started, component)
+
+		logger.Debug("Component started", zap.String("component", component.Name))
+	}
+
+	return nil
+}
+
+// Stop stops the started components in reverse start order. Every component is stopped even when
+// others fail, and all errors are returned joined together.
+func (m *Manager) Stop(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.stop(ctx)
+}
+
+func (m *Manager) stop(ctx context.Context) error {
+	logger := kooctx.GetContextLogger(ctx)
+
+	var errs []error
+
+	for i := len(m.started) - 1; i >= 0; i-- {
+		component := m.started[i]
+
+		if err := runHook(ctx, component.Stop, component.StopTimeout); err != nil {
+			logger.Error("Failed to stop component", zap.String("component", component.Name), zap.Error(err))
+			errs = append(errs, fmt.Errorf("failed to stop %s: %w", component.Name, err))
+
+			continue
+		}
+
+		logger.Debug("Component stopped", zap.String("component", component.Name))
+	}
+
+	m.started = nil
+
+	return errors.Join(errs...)
+}
+
+// order sorts the components topologically, keeping registration order where possible.
+func (m *Manager) order() ([]Component, error) {
+	byName := make(map[string]Component, len(m.components))
+	for _, c := range m.components {
+		byName[c.Name] = c
+	}
+
+	const (
+		visiting = 1
+		visited  = 2
+	)
+
+	state := make(map[string]int, len(m.components))
+	ordered := make([]Component, 0, len(m.components))
+
+	var visit func(c Component, path []string) error
+
+	visit = func(c Component, path []string) error {
+		switch state[c.Name] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("dependency cycle: %s", strings.Join(append(path, c.Name), " -> "))
+		}
+
+		state[c.Name] = visiting
+
+		for _, name := range c.DependsOn {
+			dep, ok := byName[name]
+			if !ok {
+				return fmt.Errorf("component %s depends on unknown component %s", c.Name, name)
+			}
+
+			if err := visit(dep, append(path, c.Name)); err != nil {
+				return err
+			}
+		}
+
+		state[c.Name] = visited
+		ordered = append(ordered, c)
+
+		return nil
+	}
+
+	for _, c := range m.components {
+		if err := visit(c, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return ordered, nil
+}
+
+// runHook runs hook bounded by timeout. Hooks that ignore ctx are abandoned once it is done,
+// so a stuck component cannot block the ones after it.
+func runHook(ctx context.Context, hook Hook, timeout time.Duration) error {
+	if hook == nil {
+		return nil
+	}
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- hook(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
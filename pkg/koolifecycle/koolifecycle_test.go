@@ -0,0 +1,153 @@
+package koolifecycle_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kootic/koogo/pkg/koolifecycle"
+)
+
+func recordingComponent(name string, events *[]string, dependsOn ...string) koolifecycle.Component {
+	return koolifecycle.Component{
+		Name:      name,
+		DependsOn: dependsOn,
+		Start: func(ctx context.Context) error {
+			*events = append(*events, "start "+name)
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			*events = append(*events, "stop "+name)
+			return nil
+		},
+	}
+}
+
+func TestManagerOrder(t *testing.T) {
+	t.Parallel()
+
+	var events []string
+
+	m := koolifecycle.NewManager()
+	for _, c := range []koolifecycle.Component{
+		recordingComponent("server", &events, "database", "otel"),
+		recordingComponent("database", &events, "otel"),
+		recordingComponent("otel", &events),
+	} {
+		if err := m.Register(c); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if err := m.Start(t.Context()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := m.Stop(t.Context()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"start otel", "start database", "start server", "stop server", "stop database", "stop otel"}
+	if !reflect.DeepEqual(events, want) {
+		t.Fatalf("expected %v, got %v", want, events)
+	}
+}
+
+func TestManagerStopCollectsErrors(t *testing.T) {
+	t.Parallel()
+
+	var events []string
+
+	errCache := errors.New("cache stop failed")
+
+	m := koolifecycle.NewManager()
+	_ = m.Register(recordingComponent("database", &events))
+	_ = m.Register(koolifecycle.Component{
+		Name:      "cache",
+		DependsOn: []string{"database"},
+		Stop:      func(ctx context.Context) error { return errCache },
+	})
+	_ = m.Register(koolifecycle.Component{
+		Name:        "worker",
+		DependsOn:   []string{"cache"},
+		Stop:        func(ctx context.Context) error { time.Sleep(time.Second); return nil },
+		StopTimeout: 10 * time.Millisecond,
+	})
+
+	if err := m.Start(t.Context()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err := m.Stop(t.Context())
+	if !errors.Is(err, errCache) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected cache and timeout errors, got %v", err)
+	}
+
+	if !reflect.DeepEqual(events, []string{"start database", "stop database"}) {
+		t.Fatalf("expected database to be stopped after failures, got %v", events)
+	}
+}
+
+func TestManagerStartFailure(t *testing.T) {
+	t.Parallel()
+
+	var events []string
+
+	m := koolifecycle.NewManager()
+	_ = m.Register(recordingComponent("database", &events))
+	_ = m.Register(koolifecycle.Component{
+		Name:      "server",
+		DependsOn: []string{"database"},
+		Start:     func(ctx context.Context) error { return errors.New("port in use") },
+	})
+
+	if err := m.Start(t.Context()); err == nil || !strings.Contains(err.Error(), "failed to start server") {
+		t.Fatalf("expected start error, got %v", err)
+	}
+
+	if !reflect.DeepEqual(events, []string{"start database", "stop database"}) {
+		t.Fatalf("expected started components to be stopped, got %v", events)
+	}
+}
+
+func TestManagerInvalidDependencies(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		components []koolifecycle.Component
+		wantErr    string
+	}{
+		{
+			name:       "unknown",
+			components: []koolifecycle.Component{{Name: "server", DependsOn: []string{"database"}}},
+			wantErr:    "unknown component database",
+		},
+		{
+			name: "cycle",
+			components: []koolifecycle.Component{
+				{Name: "a", DependsOn: []string{"b"}},
+				{Name: "b", DependsOn: []string{"a"}},
+			},
+			wantErr: "dependency cycle: a -> b -> a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := koolifecycle.NewManager()
+			for _, c := range tt.components {
+				_ = m.Register(c)
+			}
+
+			if err := m.Start(t.Context()); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -2,10 +2,11 @@ package koolog
 
 import (
 	"go.uber.org/zap"
-	"go.uber.org/zap/zapcore"
 )
 
-func NewLogger(isProd bool, level zapcore.Level) (*zap.Logger, error) {
+// NewLogger builds a logger whose level is controlled by level, so callers
+// holding on to it can change the level at runtime with level.SetLevel.
+func NewLogger(isProd bool, level zap.AtomicLevel) (*zap.Logger, error) {
 	var loggerConfig zap.Config
 	if isProd {
 		loggerConfig = zap.NewProductionConfig()
@@ -13,7 +14,7 @@ func NewLogger(isProd bool, level zapcore.Level) (*zap.Logger, error) {
 		loggerConfig = zap.NewDevelopmentConfig()
 	}
 
-	loggerConfig.Level.SetLevel(level)
+	loggerConfig.Level = level
 
 	logger, err := loggerConfig.Build()
 	if err != nil {
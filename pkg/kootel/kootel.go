@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"sync/atomic"
+	"time"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
@@ -66,6 +68,7 @@ func InitializeOTel(ctx context.Context, cfg OTelConfig) (func(context.Context)
 	otel.SetTracerProvider(tp)
 	otel.SetMeterProvider(mp)
 	global.SetLoggerProvider(lp)
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(handleError))
 
 	shutdownFuncs := []func(context.Context) error{
 		lp.Shutdown,
@@ -86,6 +89,33 @@ func InitializeOTel(ctx context.Context, cfg OTelConfig) (func(context.Context)
 	}, nil
 }
 
+// exportErrorWindow is how long an error reported by the SDK, e.g. a failed export, fails CheckExport.
+const exportErrorWindow = time.Minute
+
+type sdkError struct {
+	err error
+	at  time.Time
+}
+
+var lastError atomic.Pointer[sdkError]
+
+// handleError logs errors reported by the SDK and records them for CheckExport.
+func handleError(err error) {
+	log.Printf("OpenTelemetry error: %v", err)
+	lastError.Store(&sdkError{err: err, at: time.Now()})
+}
+
+// CheckExport is a health check that fails when the SDK reported an error, such as an unreachable
+// collector, within the last minute.
+func CheckExport(ctx context.Context) error {
+	last := lastError.Load()
+	if last == nil || time.Since(last.at) > exportErrorWindow {
+		return nil
+	}
+
+	return fmt.Errorf("error reported %s ago: %w", time.Since(last.at).Round(time.Second), last.err)
+}
+
 // newResource creates a new OTEL resource with the service name and version.
 func newResource(cfg OTelConfig) *resource.Resource {
 	hostName, _ := os.Hostname()
@@ -0,0 +1,176 @@
+// Package kootls builds server TLS configs whose certificates are reloaded from disk when they change.
+package kootls
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// DefaultReloadInterval is how often the certificate files are checked for changes when Config.ReloadInterval is zero.
+const DefaultReloadInterval = 10 * time.Second
+
+// Config locates the PEM encoded files of a server certificate.
+type Config struct {
+	CertFile string
+	KeyFile  string
+	// ClientCAFile enables mutual TLS, clients must present a certificate signed by one of its CAs.
+	ClientCAFile string
+	// ReloadInterval is how often the files are checked for changes during handshakes.
+	ReloadInterval time.Duration
+	// OnReloadError is called when changed files fail to load, the previous certificates keep being served.
+	OnReloadError func(err error)
+}
+
+// Reloader serves the certificates in Config and reloads them when the files change, e.g. when
+// cert-manager or a sidecar renews them, without restarting the process. It is safe for concurrent use.
+type Reloader struct {
+	config Config
+
+	mu        sync.Mutex
+	checkedAt time.Time
+	modTimes  []time.Time
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+}
+
+// NewReloader loads the certificates in cfg and returns a Reloader serving them.
+func NewReloader(cfg Config) (*Reloader, error) {
+	if cfg.CertFile == "" || cfg.KeyFile == "" {
+		return nil, errors.New("certificate and key files are required")
+	}
+
+	if cfg.ReloadInterval <= 0 {
+		cfg.ReloadInterval = DefaultReloadInterval
+	}
+
+	r := &Reloader{config: cfg}
+
+	modTimes, err := r.statFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.load(modTimes); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// TLSConfig returns a server TLS config that picks up reloaded certificates on every handshake.
+func (r *Reloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		GetConfigForClient: r.configForClient,
+	}
+}
+
+func (r *Reloader) configForClient(*tls.ClientHelloInfo) (*tls.Config, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.reloadIfChanged()
+
+	cfg := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{*r.cert},
+	}
+
+	if r.clientCAs != nil {
+		cfg.ClientCAs = r.clientCAs
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return cfg, nil
+}
+
+// reloadIfChanged reloads the files when ReloadInterval has passed and any modification time changed.
+func (r *Reloader) reloadIfChanged() {
+	if time.Since(r.checkedAt) < r.config.ReloadInterval {
+		return
+	}
+
+	r.checkedAt = time.Now()
+
+	modTimes, err := r.statFiles()
+	if err == nil && equalTimes(modTimes, r.modTimes) {
+		return
+	}
+
+	if err == nil {
+		err = r.load(modTimes)
+	}
+
+	if err != nil && r.config.OnReloadError != nil {
+		r.config.OnReloadError(err)
+	}
+}
+
+func (r *Reloader) load(modTimes []time.Time) error {
+	cert, err := tls.LoadX509KeyPair(r.config.CertFile, r.config.KeyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load certificate: %w", err)
+	}
+
+	var clientCAs *x509.CertPool
+
+	if r.config.ClientCAFile != "" {
+		pem, err := os.ReadFile(r.config.ClientCAFile)
+		if err != nil {
+			return fmt.Errorf("failed to read client CA: %w", err)
+		}
+
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no certificates found in client CA file %s", r.config.ClientCAFile)
+		}
+	}
+
+	r.cert, r.clientCAs, r.modTimes = &cert, clientCAs, modTimes
+
+	return nil
+}
+
+func (r *Reloader) files() []string {
+	files := []string{r.config.CertFile, r.config.KeyFile}
+	if r.config.ClientCAFile != "" {
+		files = append(files, r.config.ClientCAFile)
+	}
+
+	return files
+}
+
+func (r *Reloader) statFiles() ([]time.Time, error) {
+	files := r.files()
+	modTimes := make([]time.Time, len(files))
+
+	for i, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, err
+		}
+
+		modTimes[i] = info.ModTime()
+	}
+
+	return modTimes, nil
+}
+
+func equalTimes(a, b []time.Time) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,222 @@
+// Package kooworker runs long-lived background workers, such as queue consumers and periodic tasks,
+// restarting them with exponential backoff when they fail.
+package kooworker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kootic/koogo/pkg/kooctx"
+)
+
+// Default restart backoff, doubled after every consecutive failure.
+const (
+	DefaultInitialBackoff = time.Second
+	DefaultMaxBackoff     = time.Minute
+)
+
+// Worker is a long-lived background task.
+type Worker interface {
+	// Name identifies the worker in logs and health checks and must be unique.
+	Name() string
+	// Run blocks until ctx is cancelled. Returning earlier, with or without an error, or panicking
+	// restarts the worker after a backoff.
+	Run(ctx context.Context) error
+}
+
+// Backoff configures the delay between restarts of a failed worker. The delay starts at Initial and
+// doubles up to Max, and is reset once a worker has run for longer than Max.
+type Backoff struct {
+	Initial time.Duration
+	Max     time.Duration
+}
+
+type supervised struct {
+	worker Worker
+
+	mu        sync.Mutex
+	running   bool
+	failures  int // Consecutive failures, reset with the backoff
+	lastError error
+}
+
+// Supervisor starts workers, restarts them when they fail and stops them gracefully.
+type Supervisor struct {
+	backoff Backoff
+	workers []*supervised
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+// NewSupervisor creates a Supervisor restarting failed workers with backoff. Zero values use the defaults.
+func NewSupervisor(backoff Backoff) *Supervisor {
+	if backoff.Initial <= 0 {
+		backoff.Initial = DefaultInitialBackoff
+	}
+
+	if backoff.Max < backoff.Initial {
+		backoff.Max = max(DefaultMaxBackoff, backoff.Initial)
+	}
+
+	return &Supervisor{backoff: backoff}
+}
+
+// Add registers a worker. Workers must be added before Start.
+func (s *Supervisor) Add(worker Worker) error {
+	for _, w := range s.workers {
+		if w.worker.Name() == worker.Name() {
+			return fmt.Errorf("worker %s is already registered", worker.Name())
+		}
+	}
+
+	s.workers = append(s.workers, &supervised{worker: worker})
+
+	return nil
+}
+
+// Start runs every worker in its own goroutine. Workers are stopped with Stop rather than by cancelling
+// ctx, which only provides values such as the logger, see kooctx.SetContextLogger.
+func (s *Supervisor) Start(ctx context.Context) {
+	ctx, s.cancel = context.WithCancel(context.WithoutCancel(ctx))
+
+	for _, w := range s.workers {
+		s.wg.Add(1)
+
+		go func() {
+			defer s.wg.Done()
+
+			s.supervise(ctx, w)
+		}()
+	}
+}
+
+// Stop cancels the workers and waits for them to return until ctx is done. The error lists the workers
+// that did not stop in time.
+func (s *Supervisor) Stop(ctx context.Context) error {
+	if s.cancel == nil {
+		return nil
+	}
+
+	s.cancel()
+
+	done := make(chan struct{})
+
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		var running []string
+
+		for _, w := range s.workers {
+			w.mu.Lock()
+			if w.running {
+				running = append(running, w.worker.Name())
+			}
+			w.mu.Unlock()
+		}
+
+		return fmt.Errorf("workers did not stop in time: %s: %w", strings.Join(running, ", "), ctx.Err())
+	}
+}
+
+// HealthCheck returns a check that fails while the worker is down, waiting to be restarted after a failure.
+func (s *Supervisor) HealthCheck(name string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		for _, w := range s.workers {
+			if w.worker.Name() != name {
+				continue
+			}
+
+			w.mu.Lock()
+			defer w.mu.Unlock()
+
+			if !w.running && w.lastError != nil {
+				return fmt.Errorf("restarting after %d consecutive failures: %w", w.failures, w.lastError)
+			}
+
+			return nil
+		}
+
+		return fmt.Errorf("unknown worker %s", name)
+	}
+}
+
+func (s *Supervisor) supervise(ctx context.Context, w *supervised) {
+	logger := kooctx.GetContextLogger(ctx).With(zap.String("worker", w.worker.Name()))
+	backoff := s.backoff.Initial
+	failures := 0
+
+	for {
+		w.setRunning(true, nil, failures)
+
+		started := time.Now()
+		err := run(ctx, w.worker)
+
+		if ctx.Err() != nil {
+			w.setRunning(false, nil, failures)
+
+			if err != nil && !errors.Is(err, context.Canceled) {
+				logger.Error("Worker failed while stopping", zap.Error(err))
+			}
+
+			return
+		}
+
+		if err == nil {
+			err = errors.New("worker returned before it was stopped")
+		}
+
+		// A worker that ran for longer than the max backoff recovered from earlier failures
+		if time.Since(started) > s.backoff.Max {
+			backoff = s.backoff.Initial
+			failures = 0
+		}
+
+		failures++
+		w.setRunning(false, err, failures)
+		logger.Error("Worker failed, restarting", zap.Error(err), zap.Duration("backoff", backoff))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff = min(backoff*2, s.backoff.Max)
+	}
+}
+
+func (w *supervised) setRunning(running bool, err error, failures int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.running = running
+	w.failures = failures
+
+	if err != nil {
+		w.lastError = err
+	}
+}
+
+// run runs the worker, converting a panic into an error that includes the stack trace.
+func run(ctx context.Context, worker Worker) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("worker panicked: %v\n%s", r, debug.Stack())
+		}
+	}()
+
+	return worker.Run(ctx)
+}
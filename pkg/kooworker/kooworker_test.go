@@ -0,0 +1,143 @@
+package kooworker_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kootic/koogo/pkg/kooworker"
+)
+
+type testWorker struct {
+	name string
+	runs atomic.Int32
+	run  func(ctx context.Context, attempt int32) error
+}
+
+func (w *testWorker) Name() string {
+	return w.name
+}
+
+func (w *testWorker) Run(ctx context.Context) error {
+	return w.run(ctx, w.runs.Add(1))
+}
+
+func TestSupervisorRestartsFailedWorker(t *testing.T) {
+	t.Parallel()
+
+	worker := &testWorker{
+		name: "consumer",
+		run: func(ctx context.Context, attempt int32) error {
+			switch attempt {
+			case 1:
+				panic("boom")
+			case 2:
+				return errors.New("connection lost")
+			default:
+				<-ctx.Done()
+				return ctx.Err()
+			}
+		},
+	}
+
+	supervisor := kooworker.NewSupervisor(kooworker.Backoff{Initial: time.Millisecond, Max: 10 * time.Millisecond})
+	if err := supervisor.Add(worker); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	supervisor.Start(t.Context())
+
+	deadline := time.Now().Add(time.Second)
+	for worker.runs.Load() < 3 {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected worker to be restarted, ran %d times", worker.runs.Load())
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	if err := supervisor.HealthCheck("consumer")(t.Context()); err != nil {
+		t.Fatalf("expected healthy worker after restart, got %v", err)
+	}
+
+	if err := supervisor.Stop(t.Context()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestSupervisorResetsFailuresAfterRecovery(t *testing.T) {
+	t.Parallel()
+
+	worker := &testWorker{
+		name: "consumer",
+		run: func(ctx context.Context, attempt int32) error {
+			switch attempt {
+			case 1:
+				return errors.New("connection refused")
+			case 2:
+				// Runs for longer than the max backoff before failing again
+				time.Sleep(300 * time.Millisecond)
+				return errors.New("connection lost")
+			default:
+				<-ctx.Done()
+				return ctx.Err()
+			}
+		},
+	}
+
+	supervisor := kooworker.NewSupervisor(kooworker.Backoff{Initial: 200 * time.Millisecond, Max: 200 * time.Millisecond})
+	if err := supervisor.Add(worker); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	supervisor.Start(t.Context())
+	defer supervisor.Stop(t.Context()) //nolint:errcheck // Stopping is not under test
+
+	var healthErr error
+
+	deadline := time.Now().Add(2 * time.Second)
+	for healthErr == nil || worker.runs.Load() < 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the worker to fail after recovering")
+		}
+
+		time.Sleep(time.Millisecond)
+
+		if worker.runs.Load() >= 2 {
+			healthErr = supervisor.HealthCheck("consumer")(t.Context())
+		}
+	}
+
+	if !strings.Contains(healthErr.Error(), "after 1 consecutive failures: connection lost") {
+		t.Fatalf("expected the failure count to be reset, got %v", healthErr)
+	}
+}
+
+func TestSupervisorStopTimeout(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	defer close(release)
+
+	worker := &testWorker{
+		name: "stuck",
+		run: func(ctx context.Context, attempt int32) error {
+			<-release
+			return nil
+		},
+	}
+
+	supervisor := kooworker.NewSupervisor(kooworker.Backoff{})
+	_ = supervisor.Add(worker)
+	supervisor.Start(t.Context())
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := supervisor.Stop(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected stop to time out, got %v", err)
+	}
+}
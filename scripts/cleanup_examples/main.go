@@ -8,7 +8,7 @@
 //  1. Deletes all files matching koo_*.go and *_koo_*.sql
 //  2. Parses Go files and removes Koo-prefixed declarations (types, funcs, vars, consts)
 //  3. Removes struct fields with Koo-prefixed types
-//  4. Removes route entries containing "/koo/"
+//  4. Removes route and route group entries whose path or prefix contains "/koo/"
 //  5. Cleans up unused imports
 //
 // The script respects the convention:
@@ -462,8 +462,8 @@ func removeKooRoutes(block *ast.BlockStmt) bool {
 		if compLit, ok := n.(*ast.CompositeLit); ok {
 			if arrayType, ok := compLit.Type.(*ast.ArrayType); ok {
 				if ident, ok := arrayType.Elt.(*ast.Ident); ok {
-					if ident.Name == "route" {
-						// Filter out route entries with /koo/ path
+					if ident.Name == "route" || ident.Name == "routeGroup" {
+						// Filter out route and route group entries with /koo/ path or prefix
 						var newElts []ast.Expr
 						for _, elt := range compLit.Elts {
 							if routeLit, ok := elt.(*ast.CompositeLit); ok {
@@ -492,7 +492,7 @@ func routeHasKooPath(routeLit *ast.CompositeLit) bool {
 	for _, elt := range routeLit.Elts {
 		if kv, ok := elt.(*ast.KeyValueExpr); ok {
 			if ident, ok := kv.Key.(*ast.Ident); ok {
-				if ident.Name == "Path" {
+				if ident.Name == "Path" || ident.Name == "Prefix" {
 					if lit, ok := kv.Value.(*ast.BasicLit); ok {
 						if strings.Contains(lit.Value, "/koo/") {
 							return true
@@ -25,7 +25,7 @@ const docTemplate = `{
     "paths": {
         "/v1/health": {
             "get": {
-                "description": "Returns the health status of the application",
+                "description": "Returns the health status of the application. Failing critical checks return 503,\nfailing non-critical checks only degrade the status.",
                 "consumes": [
                     "application/json"
                 ],
@@ -36,12 +36,26 @@ const docTemplate = `{
                     "Health"
                 ],
                 "summary": "Health check endpoint",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Include the status, latency and last error of every check",
+                        "name": "verbose",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_kootic_koogo_pkg_koohealth.Report"
+                        }
                     },
                     "503": {
-                        "description": "Service Unavailable"
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_kootic_koogo_pkg_koohealth.Report"
+                        }
                     }
                 }
             }
@@ -83,6 +97,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_kootic_koogo_pkg_koohttp.APIResponseError"
                         }
                     },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_kootic_koogo_pkg_koohttp.APIResponseError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -202,9 +222,13 @@ const docTemplate = `{
     "definitions": {
         "github_com_kootic_koogo_internal_dto.KooCreateUserRequest": {
             "type": "object",
+            "required": [
+                "firstName"
+            ],
             "properties": {
                 "firstName": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 100
                 }
             }
         },
@@ -233,16 +257,103 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_kootic_koogo_pkg_koohealth.CheckResult": {
+            "type": "object",
+            "properties": {
+                "checkedAt": {
+                    "type": "string"
+                },
+                "critical": {
+                    "type": "boolean"
+                },
+                "lastError": {
+                    "description": "LastError is the most recent failure, which is kept after the check recovers.",
+                    "type": "string"
+                },
+                "lastErrorAt": {
+                    "type": "string"
+                },
+                "latencyMs": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/github_com_kootic_koogo_pkg_koohealth.Status"
+                }
+            }
+        },
+        "github_com_kootic_koogo_pkg_koohealth.Report": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_kootic_koogo_pkg_koohealth.CheckResult"
+                    }
+                },
+                "status": {
+                    "$ref": "#/definitions/github_com_kootic_koogo_pkg_koohealth.Status"
+                }
+            }
+        },
+        "github_com_kootic_koogo_pkg_koohealth.Status": {
+            "type": "string",
+            "enum": [
+                "up",
+                "degraded",
+                "down"
+            ],
+            "x-enum-varnames": [
+                "StatusUp",
+                "StatusDegraded",
+                "StatusDown"
+            ]
+        },
         "github_com_kootic_koogo_pkg_koohttp.APIResponseError": {
             "type": "object",
             "properties": {
+                "detail": {
+                    "description": "Detail explains this occurrence of the error to the client.",
+                    "type": "string"
+                },
                 "errorCode": {
                     "type": "string"
                 },
+                "errors": {
+                    "description": "Errors lists the invalid fields of the request.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_kootic_koogo_pkg_koohttp.FieldError"
+                    }
+                },
+                "message": {
+                    "description": "Message is the client-safe summary of the error code, see ErrorDefinition.",
+                    "type": "string"
+                },
+                "requestId": {
+                    "description": "RequestID identifies the request in logs, it is set when the error is sent, see Error.",
+                    "type": "string"
+                },
                 "status": {
                     "type": "integer"
                 }
             }
+        },
+        "github_com_kootic_koogo_pkg_koohttp.FieldError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "field": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
         }
     },
     "securityDefinitions": {